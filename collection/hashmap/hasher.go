@@ -0,0 +1,51 @@
+package hashmap
+
+import "hash/maphash"
+
+// Hasher computes hashes of keys and compares them. Keys which are equal
+// must produce the same hash. Providing a hasher allows keys which are not
+// comparable (slices, maps, funcs) to be used in the map.
+type Hasher[K any] interface {
+	Hash(K) uint64
+	Equal(K, K) bool
+}
+
+type comparableHasher[K comparable] struct {
+	seed maphash.Seed
+}
+
+// Creates hasher for comparable keys backed by hash/maphash.
+func NewComparableHasher[K comparable]() Hasher[K] {
+	return comparableHasher[K]{
+		seed: maphash.MakeSeed(),
+	}
+}
+
+func (h comparableHasher[K]) Hash(key K) uint64 {
+	return maphash.Comparable(h.seed, key)
+}
+
+func (h comparableHasher[K]) Equal(a, b K) bool {
+	return a == b
+}
+
+type funcHasher[K any] struct {
+	hash  func(K) uint64
+	equal func(K, K) bool
+}
+
+// Creates hasher from given hash and equality functions.
+func NewFuncHasher[K any](hash func(K) uint64, equal func(K, K) bool) Hasher[K] {
+	return funcHasher[K]{
+		hash:  hash,
+		equal: equal,
+	}
+}
+
+func (h funcHasher[K]) Hash(key K) uint64 {
+	return h.hash(key)
+}
+
+func (h funcHasher[K]) Equal(a, b K) bool {
+	return h.equal(a, b)
+}
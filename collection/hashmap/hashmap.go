@@ -0,0 +1,95 @@
+package hashmap
+
+import (
+	"iter"
+
+	"github.com/igumus/gdsa/types"
+)
+
+// hashMap is an immutable hash array mapped trie. Every update returns a new
+// version sharing unchanged nodes with the original one.
+type hashMap[K, V any] struct {
+	count  int
+	root   node[K, V]
+	hasher Hasher[K]
+}
+
+func New[K comparable, V any]() types.Associative[K, V] {
+	return NewWithHasher[K, V](NewComparableHasher[K]())
+}
+
+func NewWithHasher[K, V any](h Hasher[K]) types.Associative[K, V] {
+	return &hashMap[K, V]{
+		count:  0,
+		root:   emptyNode[K, V](),
+		hasher: h,
+	}
+}
+
+func NewFromMap[K comparable, V any](items map[K]V) types.Associative[K, V] {
+	t := AsTransient(New[K, V]())
+	for k, v := range items {
+		t.Assoc(k, v)
+	}
+	return t.Persistent()
+}
+
+func (m *hashMap[K, V]) IsEmpty() bool {
+	return m == nil || m.count == 0
+}
+
+func (m *hashMap[K, V]) Count() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+func (m *hashMap[K, V]) Get(key K) (V, bool) {
+	return m.root.get(m.hasher, 0, m.hasher.Hash(key), key)
+}
+
+func (m *hashMap[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Returns a new version with key associated to value.
+func (m *hashMap[K, V]) Assoc(key K, value V) types.Associative[K, V] {
+	added := false
+	root := m.root.assoc(m.hasher, nil, 0, m.hasher.Hash(key), key, value, &added)
+	count := m.count
+	if added {
+		count++
+	}
+	return &hashMap[K, V]{count: count, root: root, hasher: m.hasher}
+}
+
+// Returns a new version without key. Returns the map itself when key does
+// not exist.
+func (m *hashMap[K, V]) Dissoc(key K) types.Associative[K, V] {
+	removed := false
+	root := m.root.dissoc(m.hasher, nil, 0, m.hasher.Hash(key), key, &removed)
+	if !removed {
+		return m
+	}
+	if root == nil {
+		root = emptyNode[K, V]()
+	}
+	return &hashMap[K, V]{count: m.count - 1, root: root, hasher: m.hasher}
+}
+
+// Returns iterator over key/value pairs of the map in unspecified order.
+// The iterator implements types.StoppableIterator and must be stopped when
+// abandoned before it is exhausted.
+func (m *hashMap[K, V]) Iterator() types.Iterator[types.Pair[K, V]] {
+	return types.FromSeq2(m.All())
+}
+
+// All returns a sequence over key/value pairs of the map in unspecified
+// order.
+func (m *hashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.each(yield)
+	}
+}
@@ -0,0 +1,176 @@
+package hashmap
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/igumus/gdsa/types"
+	"github.com/stretchr/testify/require"
+)
+
+func requireSame(t *testing.T, expected map[int]int, m types.Associative[int, int]) {
+	t.Helper()
+	require.Equal(t, len(expected), m.Count())
+	require.Equal(t, len(expected) == 0, m.IsEmpty())
+	for k, v := range expected {
+		value, ok := m.Get(k)
+		require.True(t, ok, "key %d", k)
+		require.Equal(t, v, value)
+	}
+	collected := make(map[int]int)
+	for k, v := range m.All() {
+		collected[k] = v
+	}
+	require.Equal(t, expected, collected)
+}
+
+func TestHashMapAssocDissoc(t *testing.T) {
+	m := New[string, int]()
+	require.True(t, m.IsEmpty())
+	require.False(t, m.Contains("a"))
+
+	other := m.Assoc("a", 1).Assoc("b", 2)
+	require.True(t, m.IsEmpty())
+	require.Equal(t, 2, other.Count())
+	require.True(t, other.Contains("a"))
+
+	replaced := other.Assoc("a", 10)
+	require.Equal(t, 2, replaced.Count())
+	value, _ := replaced.Get("a")
+	require.Equal(t, 10, value)
+	value, _ = other.Get("a")
+	require.Equal(t, 1, value)
+
+	removed := replaced.Dissoc("a")
+	require.Equal(t, 1, removed.Count())
+	require.False(t, removed.Contains("a"))
+	require.Equal(t, removed, removed.Dissoc("missing"))
+	require.True(t, removed.Dissoc("b").IsEmpty())
+}
+
+func TestHashMapCollisions(t *testing.T) {
+	h := NewFuncHasher(func(k int) uint64 { return uint64(k % 4) }, func(a, b int) bool { return a == b })
+	m := NewWithHasher[int, int](h)
+	expected := make(map[int]int)
+	for i := 0; i < 64; i++ {
+		m = m.Assoc(i, i*i)
+		expected[i] = i * i
+	}
+	requireSame(t, expected, m)
+
+	for i := 0; i < 64; i += 3 {
+		m = m.Dissoc(i)
+		delete(expected, i)
+	}
+	requireSame(t, expected, m)
+}
+
+func TestHashMapNonComparableKeys(t *testing.T) {
+	h := NewFuncHasher(func(k []byte) uint64 {
+		f := fnv.New64a()
+		f.Write(k)
+		return f.Sum64()
+	}, slices.Equal[[]byte])
+	m := NewWithHasher[[]byte, string](h)
+	m = m.Assoc([]byte("key"), "value")
+	value, ok := m.Get([]byte("key"))
+	require.True(t, ok)
+	require.Equal(t, "value", value)
+	require.False(t, m.Contains([]byte("other")))
+}
+
+func TestHashMapIterator(t *testing.T) {
+	m := NewFromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	collected := make(map[string]int)
+	it := m.Iterator()
+	for it.HasNext() {
+		pair := it.Next()
+		collected[pair.Key] = pair.Value
+	}
+	require.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, collected)
+
+	abandoned := m.Iterator()
+	require.True(t, abandoned.HasNext())
+	stoppable, ok := abandoned.(types.StoppableIterator[types.Pair[string, int]])
+	require.True(t, ok)
+	stoppable.Stop()
+	require.False(t, abandoned.HasNext())
+}
+
+func TestHashMapTransient(t *testing.T) {
+	m := New[int, int]()
+	tr := AsTransient(m)
+	expected := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		tr.Assoc(i, i)
+		expected[i] = i
+	}
+	for i := 0; i < 1000; i += 2 {
+		tr.Dissoc(i)
+		delete(expected, i)
+	}
+	other := tr.Persistent()
+	require.Panics(t, func() { tr.Assoc(1, 1) })
+	require.True(t, m.IsEmpty())
+	requireSame(t, expected, other)
+
+	// transient over existing map must not leak into it
+	again := AsTransient(other).Assoc(1, -1).Dissoc(3).Persistent()
+	requireSame(t, expected, other)
+	value, _ := again.Get(1)
+	require.Equal(t, -1, value)
+	require.False(t, again.Contains(3))
+}
+
+// Applies random operations to both hash map and builtin map, keeping every
+// intermediate version around to check that updates never leak into older
+// versions.
+func TestHashMapProperties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	// small key space with a weak hasher exercises collisions and collapsing
+	h := NewFuncHasher(func(k int) uint64 { return uint64(k%97) * 0x9E3779B97F4A7C15 }, func(a, b int) bool { return a == b })
+	type version struct {
+		coll     types.Associative[int, int]
+		expected map[int]int
+	}
+	history := []version{{coll: NewWithHasher[int, int](h), expected: map[int]int{}}}
+
+	for step := 0; step < 2000; step++ {
+		current := history[rnd.Intn(len(history))]
+		coll := current.coll
+		expected := make(map[int]int, len(current.expected))
+		for k, v := range current.expected {
+			expected[k] = v
+		}
+		switch op := rnd.Intn(10); {
+		case op < 6:
+			k, v := rnd.Intn(500), rnd.Int()
+			coll = coll.Assoc(k, v)
+			expected[k] = v
+		case op < 9:
+			k := rnd.Intn(500)
+			coll = coll.Dissoc(k)
+			delete(expected, k)
+		default:
+			tr := AsTransient(coll)
+			for i := 0; i < 50; i++ {
+				k := rnd.Intn(500)
+				if rnd.Intn(2) == 0 {
+					tr.Assoc(k, i)
+					expected[k] = i
+				} else {
+					tr.Dissoc(k)
+					delete(expected, k)
+				}
+			}
+			coll = tr.Persistent()
+		}
+		history = append(history, version{coll: coll, expected: expected})
+	}
+
+	for _, v := range history {
+		requireSame(t, v.expected, v.coll)
+	}
+}
@@ -0,0 +1,299 @@
+package hashmap
+
+import "math/bits"
+
+const (
+	bitsPerLevel = 5
+	mask         = 1<<bitsPerLevel - 1
+)
+
+// owner identifies the transient which is allowed to mutate a trie node in
+// place. It must not be zero sized, otherwise distinct owners may share an
+// address.
+type owner struct {
+	_ byte
+}
+
+// node is either a bitmap indexed node or a collision node holding keys
+// which share the same hash. Updates return the node itself when nothing
+// changed, so callers can skip copying the path above it.
+type node[K, V any] interface {
+	get(h Hasher[K], shift uint, hash uint64, key K) (V, bool)
+	assoc(h Hasher[K], edit *owner, shift uint, hash uint64, key K, value V, added *bool) node[K, V]
+	dissoc(h Hasher[K], edit *owner, shift uint, hash uint64, key K, removed *bool) node[K, V]
+	// Returns the only key/value pair of the node, used to collapse paths
+	// left with a single entry after removal.
+	single() (K, V, bool)
+	each(yield func(K, V) bool) bool
+}
+
+// entry holds either a key/value pair or a child node.
+type entry[K, V any] struct {
+	key   K
+	value V
+	child node[K, V]
+}
+
+type bitmapNode[K, V any] struct {
+	edit    *owner
+	bitmap  uint32
+	entries []entry[K, V]
+}
+
+func emptyNode[K, V any]() *bitmapNode[K, V] {
+	return &bitmapNode[K, V]{}
+}
+
+func bitpos(shift uint, hash uint64) uint32 {
+	return 1 << ((hash >> shift) & mask)
+}
+
+func (n *bitmapNode[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// Returns node itself when it is owned by given transient, otherwise returns
+// a copy owned by it.
+func (n *bitmapNode[K, V]) editable(edit *owner) *bitmapNode[K, V] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	entries := make([]entry[K, V], len(n.entries))
+	copy(entries, n.entries)
+	return &bitmapNode[K, V]{edit: edit, bitmap: n.bitmap, entries: entries}
+}
+
+func (n *bitmapNode[K, V]) get(h Hasher[K], shift uint, hash uint64, key K) (V, bool) {
+	var ret V
+	bit := bitpos(shift, hash)
+	if n.bitmap&bit == 0 {
+		return ret, false
+	}
+	e := n.entries[n.index(bit)]
+	if e.child != nil {
+		return e.child.get(h, shift+bitsPerLevel, hash, key)
+	}
+	if h.Equal(e.key, key) {
+		return e.value, true
+	}
+	return ret, false
+}
+
+func (n *bitmapNode[K, V]) assoc(h Hasher[K], edit *owner, shift uint, hash uint64, key K, value V, added *bool) node[K, V] {
+	bit := bitpos(shift, hash)
+	idx := n.index(bit)
+	if n.bitmap&bit == 0 {
+		*added = true
+		ret := n.editable(edit)
+		ret.entries = append(ret.entries, entry[K, V]{})
+		copy(ret.entries[idx+1:], ret.entries[idx:])
+		ret.entries[idx] = entry[K, V]{key: key, value: value}
+		ret.bitmap |= bit
+		return ret
+	}
+
+	e := n.entries[idx]
+	if e.child != nil {
+		child := e.child.assoc(h, edit, shift+bitsPerLevel, hash, key, value, added)
+		if child == e.child {
+			return n
+		}
+		ret := n.editable(edit)
+		ret.entries[idx].child = child
+		return ret
+	}
+
+	ret := n.editable(edit)
+	if h.Equal(e.key, key) {
+		ret.entries[idx].value = value
+		return ret
+	}
+	*added = true
+	ret.entries[idx] = entry[K, V]{
+		child: createNode(h, edit, shift+bitsPerLevel, e.key, e.value, key, value, hash),
+	}
+	return ret
+}
+
+func (n *bitmapNode[K, V]) dissoc(h Hasher[K], edit *owner, shift uint, hash uint64, key K, removed *bool) node[K, V] {
+	bit := bitpos(shift, hash)
+	if n.bitmap&bit == 0 {
+		return n
+	}
+	idx := n.index(bit)
+	e := n.entries[idx]
+	if e.child != nil {
+		child := e.child.dissoc(h, edit, shift+bitsPerLevel, hash, key, removed)
+		if child == e.child {
+			return n
+		}
+		ret := n.editable(edit)
+		if child == nil {
+			return ret.remove(idx, bit)
+		}
+		if k, v, ok := child.single(); ok {
+			ret.entries[idx] = entry[K, V]{key: k, value: v}
+		} else {
+			ret.entries[idx].child = child
+		}
+		return ret
+	}
+	if !h.Equal(e.key, key) {
+		return n
+	}
+	*removed = true
+	return n.editable(edit).remove(idx, bit)
+}
+
+// Removes entry at idx in place. Returns nil when node becomes empty.
+func (n *bitmapNode[K, V]) remove(idx int, bit uint32) node[K, V] {
+	if n.bitmap == bit {
+		return nil
+	}
+	copy(n.entries[idx:], n.entries[idx+1:])
+	n.entries[len(n.entries)-1] = entry[K, V]{}
+	n.entries = n.entries[:len(n.entries)-1]
+	n.bitmap &^= bit
+	return n
+}
+
+func (n *bitmapNode[K, V]) single() (K, V, bool) {
+	if len(n.entries) == 1 && n.entries[0].child == nil {
+		return n.entries[0].key, n.entries[0].value, true
+	}
+	var (
+		k K
+		v V
+	)
+	return k, v, false
+}
+
+func (n *bitmapNode[K, V]) each(yield func(K, V) bool) bool {
+	for _, e := range n.entries {
+		if e.child != nil {
+			if !e.child.each(yield) {
+				return false
+			}
+		} else if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Creates node holding two different keys, pushing them down until their
+// hashes diverge or storing them in a collision node when hashes are equal.
+func createNode[K, V any](h Hasher[K], edit *owner, shift uint, k1 K, v1 V, k2 K, v2 V, hash2 uint64) node[K, V] {
+	hash1 := h.Hash(k1)
+	if hash1 == hash2 {
+		return &collisionNode[K, V]{
+			edit:   edit,
+			hash:   hash1,
+			keys:   []K{k1, k2},
+			values: []V{v1, v2},
+		}
+	}
+	added := false
+	var ret node[K, V] = &bitmapNode[K, V]{edit: edit}
+	ret = ret.assoc(h, edit, shift, hash1, k1, v1, &added)
+	return ret.assoc(h, edit, shift, hash2, k2, v2, &added)
+}
+
+type collisionNode[K, V any] struct {
+	edit   *owner
+	hash   uint64
+	keys   []K
+	values []V
+}
+
+func (n *collisionNode[K, V]) find(h Hasher[K], key K) int {
+	for i, k := range n.keys {
+		if h.Equal(k, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (n *collisionNode[K, V]) editable(edit *owner) *collisionNode[K, V] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	return &collisionNode[K, V]{
+		edit:   edit,
+		hash:   n.hash,
+		keys:   append([]K{}, n.keys...),
+		values: append([]V{}, n.values...),
+	}
+}
+
+func (n *collisionNode[K, V]) get(h Hasher[K], shift uint, hash uint64, key K) (V, bool) {
+	var ret V
+	if hash != n.hash {
+		return ret, false
+	}
+	if i := n.find(h, key); i >= 0 {
+		return n.values[i], true
+	}
+	return ret, false
+}
+
+func (n *collisionNode[K, V]) assoc(h Hasher[K], edit *owner, shift uint, hash uint64, key K, value V, added *bool) node[K, V] {
+	if hash != n.hash {
+		// nest collision node into a bitmap node and add the new key beside
+		var parent node[K, V] = &bitmapNode[K, V]{
+			edit:    edit,
+			bitmap:  bitpos(shift, n.hash),
+			entries: []entry[K, V]{{child: n}},
+		}
+		return parent.assoc(h, edit, shift, hash, key, value, added)
+	}
+	ret := n.editable(edit)
+	if i := n.find(h, key); i >= 0 {
+		ret.values[i] = value
+		return ret
+	}
+	*added = true
+	ret.keys = append(ret.keys, key)
+	ret.values = append(ret.values, value)
+	return ret
+}
+
+func (n *collisionNode[K, V]) dissoc(h Hasher[K], edit *owner, shift uint, hash uint64, key K, removed *bool) node[K, V] {
+	if hash != n.hash {
+		return n
+	}
+	i := n.find(h, key)
+	if i < 0 {
+		return n
+	}
+	*removed = true
+	if len(n.keys) == 1 {
+		return nil
+	}
+	ret := n.editable(edit)
+	last := len(ret.keys) - 1
+	ret.keys[i], ret.values[i] = ret.keys[last], ret.values[last]
+	ret.keys, ret.values = ret.keys[:last], ret.values[:last]
+	return ret
+}
+
+func (n *collisionNode[K, V]) single() (K, V, bool) {
+	if len(n.keys) == 1 {
+		return n.keys[0], n.values[0], true
+	}
+	var (
+		k K
+		v V
+	)
+	return k, v, false
+}
+
+func (n *collisionNode[K, V]) each(yield func(K, V) bool) bool {
+	for i, k := range n.keys {
+		if !yield(k, n.values[i]) {
+			return false
+		}
+	}
+	return true
+}
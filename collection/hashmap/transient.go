@@ -0,0 +1,84 @@
+package hashmap
+
+import (
+	"github.com/igumus/gdsa/types"
+)
+
+// Transient is a mutable builder over the hash trie. Nodes created by a
+// transient are updated in place, so batch updates avoid copying the path
+// on every change. Calling Persistent seals the transient; using it
+// afterwards panics.
+type Transient[K, V any] struct {
+	edit   *owner
+	count  int
+	root   node[K, V]
+	hasher Hasher[K]
+}
+
+// Creates transient starting from the entries of given map. Map nodes are
+// shared and copied only when the transient updates them, so the original
+// map is never modified.
+func AsTransient[K, V any](coll types.Associative[K, V]) *Transient[K, V] {
+	m, ok := coll.(*hashMap[K, V])
+	if !ok {
+		panic("hashmap: transient requires a map created by this package")
+	}
+	return &Transient[K, V]{
+		edit:   &owner{},
+		count:  m.count,
+		root:   m.root,
+		hasher: m.hasher,
+	}
+}
+
+func (t *Transient[K, V]) ensureEditable() {
+	if t.edit == nil {
+		panic("hashmap: transient used after Persistent call")
+	}
+}
+
+func (t *Transient[K, V]) Count() int {
+	t.ensureEditable()
+	return t.count
+}
+
+func (t *Transient[K, V]) Get(key K) (V, bool) {
+	t.ensureEditable()
+	return t.root.get(t.hasher, 0, t.hasher.Hash(key), key)
+}
+
+// Associates key to value in place.
+func (t *Transient[K, V]) Assoc(key K, value V) *Transient[K, V] {
+	t.ensureEditable()
+	added := false
+	t.root = t.root.assoc(t.hasher, t.edit, 0, t.hasher.Hash(key), key, value, &added)
+	if added {
+		t.count++
+	}
+	return t
+}
+
+// Removes key in place.
+func (t *Transient[K, V]) Dissoc(key K) *Transient[K, V] {
+	t.ensureEditable()
+	removed := false
+	t.root = t.root.dissoc(t.hasher, t.edit, 0, t.hasher.Hash(key), key, &removed)
+	if t.root == nil {
+		t.root = emptyNode[K, V]()
+	}
+	if removed {
+		t.count--
+	}
+	return t
+}
+
+// Seals the transient and returns an immutable map holding its entries.
+func (t *Transient[K, V]) Persistent() types.Associative[K, V] {
+	t.ensureEditable()
+	t.edit = nil
+	return &hashMap[K, V]{
+		count:  t.count,
+		root:   t.root,
+		hasher: t.hasher,
+	}
+}
@@ -0,0 +1,188 @@
+package list
+
+import (
+	"github.com/igumus/gdsa/types"
+)
+
+// Functions in this file treat immutable lists persistently, returning new
+// lists which share nodes with the original whenever possible. Mutable
+// lists are updated in place and returned, except for functions changing
+// the item type (Map, FlatMap, Zip) which return a new mutable list.
+
+// Collects items of the list from head to tail.
+func toSlice[T comparable](l types.List[T]) []T {
+	ret := make([]T, 0, l.Count())
+	for item := range l.All() {
+		ret = append(ret, item)
+	}
+	return ret
+}
+
+// Returns node chain backing the list. Nodes are never mutated, so they can
+// be shared by any number of lists.
+func nodesOf[T comparable](l types.List[T]) types.List[T] {
+	if w, ok := l.(*list[T]); ok {
+		l = w.root
+	}
+	if l == nil {
+		return emptyNode[T]()
+	}
+	return l
+}
+
+func isMutable[T comparable](l types.List[T]) bool {
+	_, ok := l.(*list[T])
+	return ok
+}
+
+// Replaces contents of mutable list with given root, or returns root itself
+// for immutable list.
+func replace[T comparable](l types.List[T], root types.List[T]) types.List[T] {
+	if w, ok := l.(*list[T]); ok {
+		w.root = root
+		return w
+	}
+	return root
+}
+
+// Returns a list holding results of applying f to each item.
+func Map[T, R comparable](l types.List[T], f func(T) R) types.List[R] {
+	items := make([]R, 0, l.Count())
+	for item := range l.All() {
+		items = append(items, f(item))
+	}
+	return NewListFromArray(isMutable(l), items)
+}
+
+// Returns a list holding concatenation of the lists produced by applying f
+// to each item.
+func FlatMap[T, R comparable](l types.List[T], f func(T) types.List[R]) types.List[R] {
+	items := make([]R, 0, l.Count())
+	for item := range l.All() {
+		items = append(items, toSlice(f(item))...)
+	}
+	return NewListFromArray(isMutable(l), items)
+}
+
+// Returns a list holding items for which predicate returns true.
+func Filter[T comparable](l types.List[T], f func(T) bool) types.List[T] {
+	items := make([]T, 0, l.Count())
+	for item := range l.All() {
+		if f(item) {
+			items = append(items, item)
+		}
+	}
+	return replace(l, newListFromArray(items))
+}
+
+// Reduces items from head to tail.
+func FoldLeft[T comparable, A any](l types.List[T], initial A, f func(A, T) A) A {
+	acc := initial
+	for item := range l.All() {
+		acc = f(acc, item)
+	}
+	return acc
+}
+
+// Reduces items from tail to head.
+func FoldRight[T comparable, A any](l types.List[T], initial A, f func(T, A) A) A {
+	items := toSlice(l)
+	acc := initial
+	for i := len(items) - 1; i >= 0; i-- {
+		acc = f(items[i], acc)
+	}
+	return acc
+}
+
+// Returns a list holding items in reverse order.
+func Reverse[T comparable](l types.List[T]) types.List[T] {
+	var root types.List[T] = emptyNode[T]()
+	for item := range l.All() {
+		root = root.Add(item)
+	}
+	return replace(l, root)
+}
+
+// Returns a list holding items of l followed by items of other. Result
+// shares nodes of other.
+func Concat[T comparable](l, other types.List[T]) types.List[T] {
+	root := nodesOf(other)
+	items := toSlice(l)
+	for i := len(items) - 1; i >= 0; i-- {
+		root = root.Add(items[i])
+	}
+	return replace(l, root)
+}
+
+// Returns a list holding first n items.
+func Take[T comparable](l types.List[T], n int) types.List[T] {
+	items := make([]T, 0, max(n, 0))
+	for item := range l.All() {
+		if len(items) >= n {
+			break
+		}
+		items = append(items, item)
+	}
+	return replace(l, newListFromArray(items))
+}
+
+// Returns a list without first n items. Result shares nodes with l.
+func Drop[T comparable](l types.List[T], n int) types.List[T] {
+	root := nodesOf(l)
+	for i := 0; i < n && !root.IsEmpty(); i++ {
+		root = restOf(root)
+	}
+	return replace(l, root)
+}
+
+// Returns a list of pairs holding items at the same position of both lists.
+// Result is as long as the shorter list.
+func Zip[A, B comparable](l types.List[A], other types.List[B]) types.List[types.Pair[A, B]] {
+	items := make([]types.Pair[A, B], 0, min(l.Count(), other.Count()))
+	it := types.NewListIterator(other)
+	for item := range l.All() {
+		if !it.HasNext() {
+			break
+		}
+		items = append(items, types.Pair[A, B]{Key: item, Value: it.Next()})
+	}
+	return NewListFromArray(isMutable(l), items)
+}
+
+// Returns item at index i, counting from head.
+func Nth[T comparable](l types.List[T], i int) (T, bool) {
+	var ret T
+	if i < 0 {
+		return ret, false
+	}
+	for item := range l.All() {
+		if i == 0 {
+			return item, true
+		}
+		i--
+	}
+	return ret, false
+}
+
+// Returns index of the first occurrence of v, or -1 when v does not exist.
+func IndexOf[T comparable](l types.List[T], v T) int {
+	i := 0
+	for item := range l.All() {
+		if item == v {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// Returns the item at the tail of the list.
+func Last[T comparable](l types.List[T]) (T, bool) {
+	var ret T
+	found := false
+	for item := range l.All() {
+		ret = item
+		found = true
+	}
+	return ret, found
+}
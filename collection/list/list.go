@@ -1,6 +1,8 @@
 package list
 
 import (
+	"iter"
+
 	"github.com/igumus/gdsa/types"
 )
 
@@ -62,6 +64,35 @@ func (l *list[T]) Add(value T) types.List[T] {
 	return l
 }
 
+func (l *list[T]) Remove(value T) types.List[T] {
+	l.root = nodesOf[T](l).Remove(value)
+	return l
+}
+
+func (l *list[T]) RemoveAt(i int) (types.List[T], bool) {
+	root, ok := nodesOf[T](l).RemoveAt(i)
+	if ok {
+		l.root = root
+	}
+	return l, ok
+}
+
+func (l *list[T]) InsertAt(i int, value T) (types.List[T], bool) {
+	root, ok := nodesOf[T](l).InsertAt(i, value)
+	if ok {
+		l.root = root
+	}
+	return l, ok
+}
+
+func (l *list[T]) Update(i int, value T) (types.List[T], bool) {
+	root, ok := nodesOf[T](l).Update(i, value)
+	if ok {
+		l.root = root
+	}
+	return l, ok
+}
+
 func (l *list[T]) ContainsValue(v T) bool {
 	if l == nil || l.root == nil {
 		return false
@@ -80,3 +111,11 @@ func (l *list[T]) Equals(o types.List[T]) bool {
 	// other is type of node
 	return l.root.Equals(o)
 }
+
+// All returns a sequence over the items of the list, from head to tail.
+func (l *list[T]) All() iter.Seq[T] {
+	if l == nil || l.root == nil {
+		return func(yield func(T) bool) {}
+	}
+	return l.root.All()
+}
@@ -142,3 +142,164 @@ func TestListFilter(t *testing.T) {
 	assert.Equal(t, expected.Count(), output.Count())
 	assert.True(t, output.Equals(expected))
 }
+
+func TestListAll(t *testing.T) {
+	for _, mutable := range []bool{true, false} {
+		list := NewListFromArray(mutable, []int{1, 2, 3})
+		collected := make([]int, 0)
+		for v := range list.All() {
+			collected = append(collected, v)
+		}
+		require.Equal(t, []int{1, 2, 3}, collected)
+
+		for range NewList[int](mutable).All() {
+			require.Fail(t, "empty list should not yield")
+		}
+	}
+}
+
+func TestListFunctions(t *testing.T) {
+	for _, mutable := range []bool{false, true} {
+		source := func() types.List[int] {
+			return NewListFromArray(mutable, []int{1, 2, 3, 4})
+		}
+
+		mapped := Map(source(), transducer.IntStringfy)
+		require.Equal(t, []string{"1", "2", "3", "4"}, toSlice(mapped))
+
+		flat := FlatMap(source(), func(i int) types.List[int] {
+			return NewListFromArray(false, []int{i, i})
+		})
+		require.Equal(t, []int{1, 1, 2, 2, 3, 3, 4, 4}, toSlice(flat))
+
+		require.Equal(t, []int{2, 4}, toSlice(Filter(source(), transducer.IsEven)))
+		require.Equal(t, []int{4, 3, 2, 1}, toSlice(Reverse(source())))
+		require.Equal(t, []int{1, 2, 3, 4, 5, 6}, toSlice(Concat(source(), NewListFromArray(mutable, []int{5, 6}))))
+		require.Equal(t, []int{1, 2}, toSlice(Take(source(), 2)))
+		require.Equal(t, []int{}, toSlice(Take(source(), 0)))
+		require.Equal(t, []int{3, 4}, toSlice(Drop(source(), 2)))
+		require.Equal(t, []int{}, toSlice(Drop(source(), 10)))
+
+		zipped := Zip(source(), NewListFromArray(mutable, []string{"a", "b"}))
+		require.Equal(t, []types.Pair[int, string]{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}}, toSlice(zipped))
+
+		require.Equal(t, 10, FoldLeft(source(), 0, func(acc, i int) int { return acc + i }))
+		require.Equal(t, "1234", FoldLeft(source(), "", func(acc string, i int) string { return acc + transducer.IntStringfy(i) }))
+		require.Equal(t, "4321", FoldRight(source(), "", func(i int, acc string) string { return acc + transducer.IntStringfy(i) }))
+
+		v, ok := Nth(source(), 2)
+		require.True(t, ok)
+		require.Equal(t, 3, v)
+		_, ok = Nth(source(), 4)
+		require.False(t, ok)
+		require.Equal(t, 1, IndexOf(source(), 2))
+		require.Equal(t, -1, IndexOf(source(), 5))
+		v, ok = Last(source())
+		require.True(t, ok)
+		require.Equal(t, 4, v)
+		_, ok = Last(NewList[int](mutable))
+		require.False(t, ok)
+	}
+}
+
+func TestListFunctionsPersistence(t *testing.T) {
+	immutable := NewListFromArray(false, []int{1, 2, 3, 4})
+	require.Equal(t, []int{3, 4}, toSlice(Drop(immutable, 2)))
+	require.Equal(t, []int{4, 3, 2, 1}, toSlice(Reverse(immutable)))
+	require.Equal(t, []int{1, 2, 3, 4}, toSlice(immutable))
+
+	// tail of immutable list is shared by the concatenation
+	tail := NewListFromArray(false, []int{5, 6})
+	concat := Concat(immutable, tail)
+	require.Same(t, tail, Drop(concat, 4))
+
+	mutable := NewListFromArray(true, []int{1, 2, 3, 4})
+	other := Filter(mutable, transducer.IsOdd)
+	require.Same(t, mutable, other)
+	require.Equal(t, []int{1, 3}, toSlice(mutable))
+	Reverse(mutable)
+	require.Equal(t, []int{3, 1}, toSlice(mutable))
+}
+
+func TestListUpdates(t *testing.T) {
+	for _, mutable := range []bool{false, true} {
+		source := func() types.List[int] {
+			return NewListFromArray(mutable, []int{1, 2, 3, 2})
+		}
+
+		require.Equal(t, []int{1, 3, 2}, toSlice(source().Remove(2)))
+		require.Equal(t, []int{1, 2, 3, 2}, toSlice(source().Remove(5)))
+
+		removed, ok := source().RemoveAt(0)
+		require.True(t, ok)
+		require.Equal(t, []int{2, 3, 2}, toSlice(removed))
+		removed, ok = source().RemoveAt(3)
+		require.True(t, ok)
+		require.Equal(t, []int{1, 2, 3}, toSlice(removed))
+		require.Equal(t, 3, removed.Count())
+		_, ok = source().RemoveAt(4)
+		require.False(t, ok)
+
+		inserted, ok := source().InsertAt(2, 9)
+		require.True(t, ok)
+		require.Equal(t, []int{1, 2, 9, 3, 2}, toSlice(inserted))
+		inserted, ok = source().InsertAt(4, 9)
+		require.True(t, ok)
+		require.Equal(t, []int{1, 2, 3, 2, 9}, toSlice(inserted))
+		require.Equal(t, 5, inserted.Count())
+		inserted, ok = NewList[int](mutable).InsertAt(0, 9)
+		require.True(t, ok)
+		require.Equal(t, []int{9}, toSlice(inserted))
+		_, ok = source().InsertAt(-1, 9)
+		require.False(t, ok)
+
+		updated, ok := source().Update(1, 9)
+		require.True(t, ok)
+		require.Equal(t, []int{1, 9, 3, 2}, toSlice(updated))
+		_, ok = source().Update(4, 9)
+		require.False(t, ok)
+	}
+}
+
+func TestListUpdatesPersistence(t *testing.T) {
+	immutable := NewListFromArray(false, []int{1, 2, 3, 4})
+	updated, _ := immutable.Update(1, 9)
+	require.Equal(t, []int{1, 2, 3, 4}, toSlice(immutable))
+	require.Equal(t, []int{1, 9, 3, 4}, toSlice(updated))
+	// nodes after the updated index are shared
+	require.Same(t, Drop(immutable, 2), Drop(updated, 2))
+
+	mutable := NewListFromArray(true, []int{1, 2, 3, 4})
+	other, ok := mutable.RemoveAt(1)
+	require.True(t, ok)
+	require.Same(t, mutable, other)
+	require.Equal(t, []int{1, 3, 4}, toSlice(mutable))
+	mutable.InsertAt(0, 0)
+	mutable.Update(3, 5)
+	mutable.Remove(3)
+	require.Equal(t, []int{0, 1, 5}, toSlice(mutable))
+}
+
+func TestListLazySeq(t *testing.T) {
+	next := 0
+	naturals := types.NewLazySeqFromFunc(func() (int, bool) {
+		next++
+		return next - 1, true
+	})
+
+	require.Equal(t, []int{0, 1, 2}, toSlice(Take(naturals, 3)))
+	require.Equal(t, 5, Drop(naturals, 5).Get())
+	v, ok := Nth(naturals, 10)
+	require.True(t, ok)
+	require.Equal(t, 10, v)
+	require.Equal(t, 11, next)
+
+	finite := types.NewLazySeqFromIterator(types.NewSliceIterator([]int{1, 2, 3}))
+	require.Equal(t, []string{"1", "2", "3"}, toSlice(Map(finite, transducer.IntStringfy)))
+	require.Equal(t, []int{2}, toSlice(Filter(finite, transducer.IsEven)))
+	require.True(t, finite.Equals(NewListFromArray(false, []int{1, 2, 3})))
+	require.True(t, NewListFromArray(false, []int{1, 2, 3}).Equals(finite))
+
+	evens := transducer.Reduce(transducer.Filter(transducer.IsEven)(transducer.Append[int]), []int{}, types.NewListIterator(Take(naturals, 6)))
+	require.Equal(t, []int{0, 2, 4}, evens)
+}
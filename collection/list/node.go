@@ -1,6 +1,8 @@
 package list
 
 import (
+	"iter"
+
 	"github.com/igumus/gdsa/types"
 )
 
@@ -64,6 +66,77 @@ func (n *node[T]) Add(val T) types.List[T] {
 	}
 }
 
+// Returns first i values of the list along with the list starting at index
+// i. Returned list shares nodes with the original one.
+func (n *node[T]) split(i int) ([]T, types.List[T]) {
+	prefix := make([]T, 0, i)
+	var rest types.List[T] = n
+	for len(prefix) < i {
+		prefix = append(prefix, rest.Get())
+		rest = restOf(rest)
+	}
+	return prefix, rest
+}
+
+// Returns rest of the list, using empty node instead of nil at the tail.
+func restOf[T comparable](l types.List[T]) types.List[T] {
+	if rest := l.Rest(); rest != nil {
+		return rest
+	}
+	return emptyNode[T]()
+}
+
+// Prepends given values to the list, copying them into new nodes.
+func prependAll[T comparable](rest types.List[T], values []T) types.List[T] {
+	for i := len(values) - 1; i >= 0; i-- {
+		rest = rest.Add(values[i])
+	}
+	return rest
+}
+
+// Returns a new list without the first occurrence of v. Returns the list
+// itself when v does not exist.
+func (n *node[T]) Remove(v T) types.List[T] {
+	i := 0
+	for item := range n.All() {
+		if item == v {
+			ret, _ := n.RemoveAt(i)
+			return ret
+		}
+		i++
+	}
+	return n
+}
+
+// Returns a new list without the item at index i. Nodes before the index
+// are copied, nodes after it are shared.
+func (n *node[T]) RemoveAt(i int) (types.List[T], bool) {
+	if i < 0 || i >= n.Count() {
+		return n, false
+	}
+	prefix, rest := n.split(i)
+	return prependAll(restOf(rest), prefix), true
+}
+
+// Returns a new list with v inserted at index i. Inserting at index equal
+// to Count appends v to the tail.
+func (n *node[T]) InsertAt(i int, v T) (types.List[T], bool) {
+	if i < 0 || i > n.Count() {
+		return n, false
+	}
+	prefix, rest := n.split(i)
+	return prependAll(rest.Add(v), prefix), true
+}
+
+// Returns a new list with the item at index i replaced by v.
+func (n *node[T]) Update(i int, v T) (types.List[T], bool) {
+	if i < 0 || i >= n.Count() {
+		return n, false
+	}
+	prefix, rest := n.split(i)
+	return prependAll(restOf(rest).Add(v), prefix), true
+}
+
 func (n *node[T]) Get() T {
 	var ret T
 	if !n.IsEmpty() {
@@ -117,3 +190,16 @@ func (n *node[T]) ContainsValue(v T) bool {
 
 	return false
 }
+
+// All returns a sequence over the items of the list, from head to tail.
+func (n *node[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var current types.List[T] = n
+		for current != nil && !current.IsEmpty() {
+			if !yield(current.Get()) {
+				return
+			}
+			current = current.Rest()
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package pvector
+
+const (
+	bits  = 5
+	width = 1 << bits
+	mask  = width - 1
+)
+
+// owner identifies the transient which is allowed to mutate a trie node in
+// place. It must not be zero sized, otherwise distinct owners may share an
+// address.
+type owner struct {
+	_ byte
+}
+
+// trieNode is either a branch node holding children or a leaf node holding
+// values. Leaves always hold exactly width values.
+type trieNode[T comparable] struct {
+	edit     *owner
+	children []*trieNode[T]
+	values   []T
+}
+
+func newBranch[T comparable](edit *owner) *trieNode[T] {
+	return &trieNode[T]{
+		edit:     edit,
+		children: make([]*trieNode[T], width),
+	}
+}
+
+func newLeaf[T comparable](edit *owner, values []T) *trieNode[T] {
+	return &trieNode[T]{
+		edit:   edit,
+		values: values,
+	}
+}
+
+// Copies node and assigns given owner to the copy.
+func (n *trieNode[T]) clone(edit *owner) *trieNode[T] {
+	ret := &trieNode[T]{edit: edit}
+	if n.children != nil {
+		ret.children = make([]*trieNode[T], width)
+		copy(ret.children, n.children)
+	}
+	if n.values != nil {
+		ret.values = make([]T, width)
+		copy(ret.values, n.values)
+	}
+	return ret
+}
+
+// Creates chain of branch nodes from given level down to the leaf.
+func newPath[T comparable](edit *owner, level uint, leaf *trieNode[T]) *trieNode[T] {
+	if level == 0 {
+		return leaf
+	}
+	ret := newBranch[T](edit)
+	ret.children[0] = newPath(edit, level-bits, leaf)
+	return ret
+}
+
+// Returns offset of the first item stored in tail for given count.
+func tailOffset(count int) int {
+	if count < width {
+		return 0
+	}
+	return ((count - 1) >> bits) << bits
+}
+
+// Checks whether root node has no room left for another leaf.
+func rootOverflow(count int, shift uint) bool {
+	return (count >> bits) > (1 << shift)
+}
+
+// Returns node itself when it is owned by given transient, otherwise returns
+// a copy owned by it. Persistent operations pass nil owner, so they always
+// copy the path they touch.
+func editable[T comparable](edit *owner, n *trieNode[T]) *trieNode[T] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	return n.clone(edit)
+}
+
+// Pushes leaf into the rightmost free slot of the trie. count is the number
+// of items in the vector including the items in the leaf.
+func pushTail[T comparable](edit *owner, count int, level uint, parent, leaf *trieNode[T]) *trieNode[T] {
+	ret := editable(edit, parent)
+	subidx := ((count - 1) >> level) & mask
+	var insert *trieNode[T]
+	if level == bits {
+		insert = leaf
+	} else if child := parent.children[subidx]; child != nil {
+		insert = pushTail(edit, count, level-bits, child, leaf)
+	} else {
+		insert = newPath(edit, level-bits, leaf)
+	}
+	ret.children[subidx] = insert
+	return ret
+}
+
+// Replaces the value at index i by copying the path from node to the leaf.
+func assoc[T comparable](edit *owner, level uint, n *trieNode[T], i int, v T) *trieNode[T] {
+	ret := editable(edit, n)
+	if level == 0 {
+		ret.values[i&mask] = v
+		return ret
+	}
+	subidx := (i >> level) & mask
+	ret.children[subidx] = assoc(edit, level-bits, n.children[subidx], i, v)
+	return ret
+}
+
+// Removes the rightmost leaf of the trie. count is the number of items in
+// the vector before removal. Returns nil when node becomes empty.
+func popTail[T comparable](edit *owner, count int, level uint, n *trieNode[T]) *trieNode[T] {
+	subidx := ((count - 2) >> level) & mask
+	if level > bits {
+		child := popTail(edit, count, level-bits, n.children[subidx])
+		if child == nil && subidx == 0 {
+			return nil
+		}
+		ret := editable(edit, n)
+		ret.children[subidx] = child
+		return ret
+	}
+	if subidx == 0 {
+		return nil
+	}
+	ret := editable(edit, n)
+	ret.children[subidx] = nil
+	return ret
+}
+
+// Returns the leaf values holding the index i, reading from the tail when
+// the index is stored there.
+func leafFor[T comparable](count int, shift uint, root *trieNode[T], tail []T, i int) []T {
+	if i >= tailOffset(count) {
+		return tail
+	}
+	n := root
+	for level := shift; level > 0; level -= bits {
+		n = n.children[(i>>level)&mask]
+	}
+	return n.values
+}
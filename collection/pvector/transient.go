@@ -0,0 +1,151 @@
+package pvector
+
+import (
+	"github.com/igumus/gdsa/types"
+)
+
+// Transient is a mutable builder over the vector trie. Nodes created by a
+// transient are updated in place, so batch construction avoids copying the
+// path on every update. Calling Persistent seals the transient; using it
+// afterwards panics.
+type Transient[T comparable] struct {
+	edit  *owner
+	count int
+	shift uint
+	root  *trieNode[T]
+	tail  []T
+}
+
+func NewTransient[T comparable]() *Transient[T] {
+	return AsTransient(NewVector[T]())
+}
+
+// Creates transient starting from the items of given vector. Vector nodes
+// are shared and copied only when the transient updates them, so the
+// original vector is never modified.
+func AsTransient[T comparable](coll types.Indexed[T]) *Transient[T] {
+	v, ok := coll.(*vector[T])
+	if !ok {
+		v = emptyVector[T]()
+		t := AsTransient[T](v)
+		for item := range coll.All() {
+			t.Append(item)
+		}
+		return t
+	}
+
+	edit := &owner{}
+	tail := make([]T, width)
+	copy(tail, v.tail)
+	return &Transient[T]{
+		edit:  edit,
+		count: v.count,
+		shift: v.shift,
+		root:  v.root.clone(edit),
+		tail:  tail,
+	}
+}
+
+func (t *Transient[T]) ensureEditable() {
+	if t.edit == nil {
+		panic("pvector: transient used after Persistent call")
+	}
+}
+
+func (t *Transient[T]) Count() int {
+	t.ensureEditable()
+	return t.count
+}
+
+func (t *Transient[T]) Get(i int) (T, bool) {
+	t.ensureEditable()
+	var ret T
+	if i < 0 || i >= t.count {
+		return ret, false
+	}
+	return leafFor(t.count, t.shift, t.root, t.tail, i)[i&mask], true
+}
+
+// Appends value to the end of the transient in place.
+func (t *Transient[T]) Append(value T) *Transient[T] {
+	t.ensureEditable()
+	if t.count-tailOffset(t.count) < width {
+		t.tail[t.count&mask] = value
+		t.count++
+		return t
+	}
+
+	leaf := newLeaf(t.edit, t.tail)
+	t.tail = make([]T, width)
+	t.tail[0] = value
+	if rootOverflow(t.count, t.shift) {
+		root := newBranch[T](t.edit)
+		root.children[0] = t.root
+		root.children[1] = newPath(t.edit, t.shift, leaf)
+		t.root = root
+		t.shift += bits
+	} else {
+		t.root = pushTail(t.edit, t.count, t.shift, t.root, leaf)
+	}
+	t.count++
+	return t
+}
+
+// Replaces item at index i in place. Setting index equal to Count appends
+// the value. Returns false when index is out of range.
+func (t *Transient[T]) Set(i int, value T) bool {
+	t.ensureEditable()
+	if i < 0 || i > t.count {
+		return false
+	}
+	if i == t.count {
+		t.Append(value)
+	} else if i >= tailOffset(t.count) {
+		t.tail[i&mask] = value
+	} else {
+		t.root = assoc(t.edit, t.shift, t.root, i, value)
+	}
+	return true
+}
+
+// Removes the last item in place. Popping an empty transient is a no-op.
+func (t *Transient[T]) Pop() *Transient[T] {
+	t.ensureEditable()
+	if t.count == 0 {
+		return t
+	}
+	if t.count == 1 || t.count-tailOffset(t.count) > 1 {
+		t.count--
+		return t
+	}
+
+	tail := make([]T, width)
+	copy(tail, leafFor(t.count, t.shift, t.root, t.tail, t.count-2))
+	root := popTail(t.edit, t.count, t.shift, t.root)
+	if root == nil {
+		root = newBranch[T](t.edit)
+	}
+	if t.shift > bits && root.children[1] == nil {
+		root = editable(t.edit, root.children[0])
+		t.shift -= bits
+	}
+	t.root = root
+	t.tail = tail
+	t.count--
+	return t
+}
+
+// Seals the transient and returns an immutable vector holding its items.
+func (t *Transient[T]) Persistent() types.Indexed[T] {
+	t.ensureEditable()
+	t.edit = nil
+	size := t.count - tailOffset(t.count)
+	tail := make([]T, size)
+	copy(tail, t.tail)
+	return &vector[T]{
+		count: t.count,
+		shift: t.shift,
+		root:  t.root,
+		tail:  tail,
+	}
+}
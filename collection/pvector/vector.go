@@ -0,0 +1,171 @@
+package pvector
+
+import (
+	"iter"
+
+	"github.com/igumus/gdsa/types"
+)
+
+// vector is an immutable bit-partitioned vector trie. Every update returns
+// a new version sharing unchanged nodes with the original one. Last items
+// are kept in a tail buffer, so appending and popping mostly avoid touching
+// the trie at all.
+type vector[T comparable] struct {
+	count int
+	shift uint
+	root  *trieNode[T]
+	tail  []T
+}
+
+func emptyVector[T comparable]() *vector[T] {
+	return &vector[T]{
+		count: 0,
+		shift: bits,
+		root:  newBranch[T](nil),
+		tail:  make([]T, 0),
+	}
+}
+
+func NewVector[T comparable]() types.Indexed[T] {
+	return emptyVector[T]()
+}
+
+func NewVectorFromArray[T comparable](items []T) types.Indexed[T] {
+	t := NewTransient[T]()
+	for _, item := range items {
+		t.Append(item)
+	}
+	return t.Persistent()
+}
+
+func (v *vector[T]) IsEmpty() bool {
+	return v == nil || v.count == 0
+}
+
+func (v *vector[T]) Count() int {
+	if v == nil {
+		return 0
+	}
+	return v.count
+}
+
+func (v *vector[T]) ContainsValue(value T) bool {
+	for item := range v.All() {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns item at index i in O(log32 n) time.
+func (v *vector[T]) Get(i int) (T, bool) {
+	var ret T
+	if i < 0 || i >= v.Count() {
+		return ret, false
+	}
+	return leafFor(v.count, v.shift, v.root, v.tail, i)[i&mask], true
+}
+
+// Returns a new version with the item at index i replaced by value. Setting
+// index equal to Count appends the value. Returns false when index is out of
+// range.
+func (v *vector[T]) Set(i int, value T) (types.Indexed[T], bool) {
+	if i < 0 || i > v.count {
+		return v, false
+	}
+	if i == v.count {
+		return v.Append(value), true
+	}
+	if i >= tailOffset(v.count) {
+		tail := make([]T, len(v.tail))
+		copy(tail, v.tail)
+		tail[i&mask] = value
+		return &vector[T]{count: v.count, shift: v.shift, root: v.root, tail: tail}, true
+	}
+	return &vector[T]{
+		count: v.count,
+		shift: v.shift,
+		root:  assoc(nil, v.shift, v.root, i, value),
+		tail:  v.tail,
+	}, true
+}
+
+// Returns a new version with value appended to the end.
+func (v *vector[T]) Append(value T) types.Indexed[T] {
+	if v.count-tailOffset(v.count) < width {
+		tail := make([]T, len(v.tail)+1)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = value
+		return &vector[T]{count: v.count + 1, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	leaf := newLeaf(nil, v.tail)
+	shift := v.shift
+	var root *trieNode[T]
+	if rootOverflow(v.count, v.shift) {
+		root = newBranch[T](nil)
+		root.children[0] = v.root
+		root.children[1] = newPath(nil, v.shift, leaf)
+		shift += bits
+	} else {
+		root = pushTail(nil, v.count, v.shift, v.root, leaf)
+	}
+	return &vector[T]{count: v.count + 1, shift: shift, root: root, tail: []T{value}}
+}
+
+// Returns a new version without the last item. Popping an empty vector
+// returns the vector itself.
+func (v *vector[T]) Pop() types.Indexed[T] {
+	if v.count == 0 {
+		return v
+	}
+	if v.count == 1 {
+		return emptyVector[T]()
+	}
+	if v.count-tailOffset(v.count) > 1 {
+		tail := make([]T, len(v.tail)-1)
+		copy(tail, v.tail)
+		return &vector[T]{count: v.count - 1, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	tail := leafFor(v.count, v.shift, v.root, v.tail, v.count-2)
+	root := popTail(nil, v.count, v.shift, v.root)
+	shift := v.shift
+	if root == nil {
+		root = newBranch[T](nil)
+	}
+	if shift > bits && root.children[1] == nil {
+		root = root.children[0]
+		shift -= bits
+	}
+	return &vector[T]{count: v.count - 1, shift: shift, root: root, tail: tail}
+}
+
+// Returns a new vector holding items in [start, end) range. Returns false
+// when the range is out of bounds.
+func (v *vector[T]) Slice(start, end int) (types.Indexed[T], bool) {
+	if start < 0 || end > v.count || start > end {
+		return v, false
+	}
+	t := NewTransient[T]()
+	for i := start; i < end; i++ {
+		item, _ := v.Get(i)
+		t.Append(item)
+	}
+	return t.Persistent(), true
+}
+
+// All returns a sequence over the items of the vector, from first to last.
+func (v *vector[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < v.Count(); i += width {
+			leaf := leafFor(v.count, v.shift, v.root, v.tail, i)
+			for j := 0; j < width && i+j < v.count; j++ {
+				if !yield(leaf[j]) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package pvector
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/igumus/gdsa/types"
+	"github.com/stretchr/testify/require"
+)
+
+var _ types.Indexed[int] = (*vector[int])(nil)
+
+func toSlice[T comparable](coll types.Indexed[T]) []T {
+	ret := make([]T, 0, coll.Count())
+	for item := range coll.All() {
+		ret = append(ret, item)
+	}
+	return ret
+}
+
+func requireSame(t *testing.T, expected []int, coll types.Indexed[int]) {
+	t.Helper()
+	require.Equal(t, len(expected), coll.Count())
+	require.Equal(t, len(expected) == 0, coll.IsEmpty())
+	indexed := make([]int, 0, len(expected))
+	for i := range expected {
+		value, ok := coll.Get(i)
+		require.True(t, ok)
+		indexed = append(indexed, value)
+	}
+	require.Equal(t, expected, indexed)
+	_, ok := coll.Get(len(expected))
+	require.False(t, ok)
+	require.Equal(t, expected, toSlice(coll))
+}
+
+func TestPersistentVectorEmpty(t *testing.T) {
+	v := NewVector[int]()
+	require.True(t, v.IsEmpty())
+	require.Equal(t, 0, v.Count())
+	require.False(t, v.ContainsValue(0))
+	_, ok := v.Get(0)
+	require.False(t, ok)
+	_, ok = v.Set(1, 1)
+	require.False(t, ok)
+	require.Equal(t, v, v.Pop())
+}
+
+func TestPersistentVectorAppendPop(t *testing.T) {
+	// crosses tail, first level and second level boundaries
+	size := width*width + 3*width + 7
+	versions := make([]types.Indexed[int], 0, size+1)
+	v := NewVector[int]()
+	versions = append(versions, v)
+	for i := 0; i < size; i++ {
+		v = v.Append(i)
+		versions = append(versions, v)
+	}
+
+	expected := make([]int, 0, size)
+	for i, version := range versions {
+		requireSame(t, expected, version)
+		if i < size {
+			expected = append(expected, i)
+		}
+	}
+
+	for i := size; i > 0; i-- {
+		v = v.Pop()
+		requireSame(t, expected[:i-1], v)
+	}
+	require.True(t, v.IsEmpty())
+}
+
+func TestPersistentVectorSet(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	v := NewVectorFromArray(items)
+	other, ok := v.Set(3, 30)
+	require.True(t, ok)
+	value, _ := other.Get(3)
+	require.Equal(t, 30, value)
+	requireSame(t, items, v)
+
+	appended, ok := v.Set(v.Count(), 10)
+	require.True(t, ok)
+	require.Equal(t, v.Count()+1, appended.Count())
+	require.True(t, appended.ContainsValue(10))
+	require.False(t, v.ContainsValue(10))
+}
+
+func TestPersistentVectorSlice(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	v := NewVectorFromArray(items)
+	sliced, ok := v.Slice(10, 90)
+	require.True(t, ok)
+	requireSame(t, items[10:90], sliced)
+
+	_, ok = v.Slice(90, 10)
+	require.False(t, ok)
+	_, ok = v.Slice(0, 101)
+	require.False(t, ok)
+}
+
+func TestPersistentVectorTransient(t *testing.T) {
+	items := make([]int, 2000)
+	for i := range items {
+		items[i] = i
+	}
+	v := NewVectorFromArray(items)
+	tr := AsTransient(v)
+	for i := 0; i < len(items); i += 3 {
+		require.True(t, tr.Set(i, -i))
+	}
+	for i := 0; i < 500; i++ {
+		tr.Pop()
+	}
+	other := tr.Persistent()
+	require.Panics(t, func() { tr.Append(1) })
+
+	requireSame(t, items, v)
+	expected := make([]int, 1500)
+	for i := range expected {
+		expected[i] = i
+		if i%3 == 0 {
+			expected[i] = -i
+		}
+	}
+	requireSame(t, expected, other)
+}
+
+// Applies random operations to both vector and plain slice, keeping every
+// intermediate version around to check that updates never leak into older
+// versions.
+func TestPersistentVectorProperties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	type version struct {
+		coll     types.Indexed[int]
+		expected []int
+	}
+	history := []version{{coll: NewVector[int](), expected: []int{}}}
+
+	for step := 0; step < 2000; step++ {
+		current := history[rnd.Intn(len(history))]
+		coll, expected := current.coll, current.expected
+		switch op := rnd.Intn(10); {
+		case op < 5:
+			value := rnd.Int()
+			coll = coll.Append(value)
+			expected = append(append([]int{}, expected...), value)
+		case op < 7 && len(expected) > 0:
+			i, value := rnd.Intn(len(expected)), rnd.Int()
+			coll, _ = coll.Set(i, value)
+			expected = append([]int{}, expected...)
+			expected[i] = value
+		case op < 8 && len(expected) > 0:
+			coll = coll.Pop()
+			expected = append([]int{}, expected[:len(expected)-1]...)
+		case op < 9:
+			tr := AsTransient(coll)
+			n := rnd.Intn(4 * width)
+			expected = append([]int{}, expected...)
+			for i := 0; i < n; i++ {
+				tr.Append(i)
+				expected = append(expected, i)
+			}
+			coll = tr.Persistent()
+		default:
+			start := rnd.Intn(len(expected) + 1)
+			end := start + rnd.Intn(len(expected)-start+1)
+			coll, _ = coll.Slice(start, end)
+			expected = append([]int{}, expected[start:end]...)
+		}
+		history = append(history, version{coll: coll, expected: expected})
+	}
+
+	for _, v := range history {
+		requireSame(t, v.expected, v.coll)
+	}
+}
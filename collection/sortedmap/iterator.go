@@ -0,0 +1,94 @@
+package sortedmap
+
+import (
+	"github.com/igumus/gdsa/types"
+)
+
+// nodeIterator walks the tree in order using an explicit stack holding the
+// path to the next node. Walking stops before the first key out of bounds.
+type nodeIterator[K, V any] struct {
+	stack   []*node[K, V]
+	reverse bool
+	outside func(K) bool
+}
+
+// Creates iterator starting at the first node not preceding start in the
+// walking direction. Nil start begins from the extreme node.
+func newNodeIterator[K, V any](cmp func(K, K) int, root *node[K, V], reverse bool, start *K, outside func(K) bool) *nodeIterator[K, V] {
+	it := &nodeIterator[K, V]{
+		stack:   make([]*node[K, V], 0),
+		reverse: reverse,
+		outside: outside,
+	}
+	for n := root; n != nil; {
+		if start != nil {
+			c := cmp(n.key, *start)
+			if (!reverse && c < 0) || (reverse && c > 0) {
+				// node precedes start, continue with the far subtree
+				n = it.far(n)
+				continue
+			}
+		}
+		it.stack = append(it.stack, n)
+		n = it.near(n)
+	}
+	return it
+}
+
+// Returns subtree visited first in the walking direction.
+func (it *nodeIterator[K, V]) near(n *node[K, V]) *node[K, V] {
+	if it.reverse {
+		return n.right
+	}
+	return n.left
+}
+
+// Returns subtree visited last in the walking direction.
+func (it *nodeIterator[K, V]) far(n *node[K, V]) *node[K, V] {
+	if it.reverse {
+		return n.left
+	}
+	return n.right
+}
+
+func (it *nodeIterator[K, V]) hasNext() bool {
+	if len(it.stack) == 0 {
+		return false
+	}
+	return it.outside == nil || !it.outside(it.stack[len(it.stack)-1].key)
+}
+
+func (it *nodeIterator[K, V]) next() *node[K, V] {
+	last := len(it.stack) - 1
+	ret := it.stack[last]
+	it.stack = it.stack[:last]
+	for n := it.far(ret); n != nil; n = it.near(n) {
+		it.stack = append(it.stack, n)
+	}
+	return ret
+}
+
+type pairIterator[K, V any] struct {
+	nodes *nodeIterator[K, V]
+}
+
+func (it *pairIterator[K, V]) HasNext() bool {
+	return it.nodes.hasNext()
+}
+
+func (it *pairIterator[K, V]) Next() types.Pair[K, V] {
+	n := it.nodes.next()
+	return types.Pair[K, V]{Key: n.key, Value: n.value}
+}
+
+type keyIterator[K, V any] struct {
+	nodes *nodeIterator[K, V]
+}
+
+func (it *keyIterator[K, V]) HasNext() bool {
+	return it.nodes.hasNext()
+}
+
+func (it *keyIterator[K, V]) Next() K {
+	return it.nodes.next().key
+}
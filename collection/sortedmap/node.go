@@ -0,0 +1,158 @@
+package sortedmap
+
+// Balance parameters of the weight-balanced tree. A node is balanced when
+// neither subtree is more than delta times heavier than the other; gamma
+// decides between single and double rotations.
+const (
+	delta = 3
+	gamma = 2
+)
+
+// node is an immutable tree node. size holds the number of nodes in the
+// subtree rooted at the node and drives both balancing and rank queries.
+type node[K, V any] struct {
+	key   K
+	value V
+	size  int
+	left  *node[K, V]
+	right *node[K, V]
+}
+
+func size[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func newNode[K, V any](key K, value V, left, right *node[K, V]) *node[K, V] {
+	return &node[K, V]{
+		key:   key,
+		value: value,
+		size:  size(left) + size(right) + 1,
+		left:  left,
+		right: right,
+	}
+}
+
+// Creates node from given parts restoring the weight balance invariant,
+// assuming subtrees were balanced and differ from a balanced state by at
+// most one insertion or removal.
+func balance[K, V any](key K, value V, left, right *node[K, V]) *node[K, V] {
+	sl, sr := size(left), size(right)
+	switch {
+	case sl+sr <= 1:
+		return newNode(key, value, left, right)
+	case sr > delta*sl:
+		return rotateLeft(key, value, left, right)
+	case sl > delta*sr:
+		return rotateRight(key, value, left, right)
+	default:
+		return newNode(key, value, left, right)
+	}
+}
+
+func rotateLeft[K, V any](key K, value V, left, right *node[K, V]) *node[K, V] {
+	rl, rr := right.left, right.right
+	if size(rl) < gamma*size(rr) {
+		return newNode(right.key, right.value, newNode(key, value, left, rl), rr)
+	}
+	return newNode(rl.key, rl.value,
+		newNode(key, value, left, rl.left),
+		newNode(right.key, right.value, rl.right, rr))
+}
+
+func rotateRight[K, V any](key K, value V, left, right *node[K, V]) *node[K, V] {
+	ll, lr := left.left, left.right
+	if size(lr) < gamma*size(ll) {
+		return newNode(left.key, left.value, ll, newNode(key, value, lr, right))
+	}
+	return newNode(lr.key, lr.value,
+		newNode(left.key, left.value, ll, lr.left),
+		newNode(key, value, lr.right, right))
+}
+
+// Returns a new tree with key associated to value, copying the path from
+// the root to the updated node.
+func insert[K, V any](cmp func(K, K) int, n *node[K, V], key K, value V) *node[K, V] {
+	if n == nil {
+		return newNode[K, V](key, value, nil, nil)
+	}
+	switch c := cmp(key, n.key); {
+	case c < 0:
+		return balance(n.key, n.value, insert(cmp, n.left, key, value), n.right)
+	case c > 0:
+		return balance(n.key, n.value, n.left, insert(cmp, n.right, key, value))
+	default:
+		return newNode(key, value, n.left, n.right)
+	}
+}
+
+// Returns a new tree without key. Returns the node itself when key does not
+// exist.
+func remove[K, V any](cmp func(K, K) int, n *node[K, V], key K) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	switch c := cmp(key, n.key); {
+	case c < 0:
+		left := remove(cmp, n.left, key)
+		if left == n.left {
+			return n
+		}
+		return balance(n.key, n.value, left, n.right)
+	case c > 0:
+		right := remove(cmp, n.right, key)
+		if right == n.right {
+			return n
+		}
+		return balance(n.key, n.value, n.left, right)
+	default:
+		return glue(n.left, n.right)
+	}
+}
+
+// Joins two balanced subtrees, every key of left being less than every key
+// of right, by lifting an extreme node of the heavier one.
+func glue[K, V any](left, right *node[K, V]) *node[K, V] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if size(left) > size(right) {
+		m := maxNode(left)
+		return balance(m.key, m.value, removeMax(left), right)
+	}
+	m := minNode(right)
+	return balance(m.key, m.value, left, removeMin(right))
+}
+
+func minNode[K, V any](n *node[K, V]) *node[K, V] {
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func maxNode[K, V any](n *node[K, V]) *node[K, V] {
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+func removeMin[K, V any](n *node[K, V]) *node[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	return balance(n.key, n.value, removeMin(n.left), n.right)
+}
+
+func removeMax[K, V any](n *node[K, V]) *node[K, V] {
+	if n.right == nil {
+		return n.left
+	}
+	return balance(n.key, n.value, n.left, removeMax(n.right))
+}
@@ -0,0 +1,128 @@
+package sortedmap
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/igumus/gdsa/types"
+)
+
+// SortedSet is an immutable set keeping its items ordered by a comparator.
+// It shares the weight-balanced tree of SortedMap, storing items as keys.
+type SortedSet[T any] struct {
+	items *SortedMap[T, struct{}]
+}
+
+// Creates empty set ordered by given comparator.
+func NewSet[T any](cmp func(T, T) int) *SortedSet[T] {
+	return &SortedSet[T]{
+		items: New[T, struct{}](cmp),
+	}
+}
+
+// Creates empty set ordered by natural order of items.
+func NewOrderedSet[T cmp.Ordered]() *SortedSet[T] {
+	return NewSet(cmp.Compare[T])
+}
+
+func (s *SortedSet[T]) IsEmpty() bool {
+	return s == nil || s.items.IsEmpty()
+}
+
+func (s *SortedSet[T]) Count() int {
+	if s == nil {
+		return 0
+	}
+	return s.items.Count()
+}
+
+func (s *SortedSet[T]) ContainsValue(v T) bool {
+	return s != nil && s.items.Contains(v)
+}
+
+// Returns a new version holding given item.
+func (s *SortedSet[T]) Add(v T) *SortedSet[T] {
+	return &SortedSet[T]{items: s.items.Assoc(v, struct{}{})}
+}
+
+// Returns a new version without given item. Returns the set itself when
+// item does not exist.
+func (s *SortedSet[T]) Remove(v T) *SortedSet[T] {
+	items := s.items.Dissoc(v)
+	if items == s.items {
+		return s
+	}
+	return &SortedSet[T]{items: items}
+}
+
+// Returns the smallest item.
+func (s *SortedSet[T]) First() (T, bool) {
+	v, _, ok := s.items.First()
+	return v, ok
+}
+
+// Returns the largest item.
+func (s *SortedSet[T]) Last() (T, bool) {
+	v, _, ok := s.items.Last()
+	return v, ok
+}
+
+// Returns the greatest item less than or equal to given item.
+func (s *SortedSet[T]) Floor(v T) (T, bool) {
+	ret, _, ok := s.items.Floor(v)
+	return ret, ok
+}
+
+// Returns the least item greater than or equal to given item.
+func (s *SortedSet[T]) Ceiling(v T) (T, bool) {
+	ret, _, ok := s.items.Ceiling(v)
+	return ret, ok
+}
+
+// Returns number of items strictly less than given item.
+func (s *SortedSet[T]) Rank(v T) int {
+	return s.items.Rank(v)
+}
+
+// Returns item at given position in order, starting from zero.
+func (s *SortedSet[T]) Select(i int) (T, bool) {
+	v, _, ok := s.items.Select(i)
+	return v, ok
+}
+
+// Returns iterator over items in ascending order.
+func (s *SortedSet[T]) Iterator() types.Iterator[T] {
+	return &keyIterator[T, struct{}]{nodes: newNodeIterator(s.items.cmp, s.items.root, false, nil, nil)}
+}
+
+// Returns iterator over items in descending order.
+func (s *SortedSet[T]) ReverseIterator() types.Iterator[T] {
+	return &keyIterator[T, struct{}]{nodes: newNodeIterator(s.items.cmp, s.items.root, true, nil, nil)}
+}
+
+// Returns iterator over items in [from, to) range in ascending order.
+func (s *SortedSet[T]) Range(from, to T) types.Iterator[T] {
+	return &keyIterator[T, struct{}]{nodes: s.items.rangeNodes(from, to)}
+}
+
+// All returns a sequence over items in ascending order.
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.items.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a sequence over items in descending order.
+func (s *SortedSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.items.Backward() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
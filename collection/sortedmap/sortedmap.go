@@ -0,0 +1,234 @@
+package sortedmap
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/igumus/gdsa/types"
+)
+
+// SortedMap is an immutable map keeping its keys ordered by a comparator.
+// It is backed by a weight-balanced tree, so lookups, updates and rank
+// queries run in O(log n) time and every update returns a new version
+// sharing unchanged nodes with the original one.
+type SortedMap[K, V any] struct {
+	root *node[K, V]
+	cmp  func(K, K) int
+}
+
+// Creates empty map ordered by given comparator. Comparator returns a
+// negative number when a < b, zero when a == b and a positive number when
+// a > b.
+func New[K, V any](cmp func(K, K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{
+		root: nil,
+		cmp:  cmp,
+	}
+}
+
+// Creates empty map ordered by natural order of keys.
+func NewOrdered[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return New[K, V](cmp.Compare[K])
+}
+
+func (m *SortedMap[K, V]) IsEmpty() bool {
+	return m == nil || m.root == nil
+}
+
+func (m *SortedMap[K, V]) Count() int {
+	if m == nil {
+		return 0
+	}
+	return size(m.root)
+}
+
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	var ret V
+	if n := m.find(key); n != nil {
+		return n.value, true
+	}
+	return ret, false
+}
+
+func (m *SortedMap[K, V]) Contains(key K) bool {
+	return m.find(key) != nil
+}
+
+// Returns a new version with key associated to value.
+func (m *SortedMap[K, V]) Assoc(key K, value V) *SortedMap[K, V] {
+	return &SortedMap[K, V]{
+		root: insert(m.cmp, m.root, key, value),
+		cmp:  m.cmp,
+	}
+}
+
+// Returns a new version without key. Returns the map itself when key does
+// not exist.
+func (m *SortedMap[K, V]) Dissoc(key K) *SortedMap[K, V] {
+	root := remove(m.cmp, m.root, key)
+	if root == m.root {
+		return m
+	}
+	return &SortedMap[K, V]{root: root, cmp: m.cmp}
+}
+
+// Returns entry with the smallest key.
+func (m *SortedMap[K, V]) First() (K, V, bool) {
+	return entryOf(minNode(m.root))
+}
+
+// Returns entry with the largest key.
+func (m *SortedMap[K, V]) Last() (K, V, bool) {
+	return entryOf(maxNode(m.root))
+}
+
+// Returns entry with the greatest key less than or equal to given key.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	var ret *node[K, V]
+	for n := m.root; n != nil; {
+		c := m.cmp(key, n.key)
+		if c == 0 {
+			return entryOf(n)
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			ret = n
+			n = n.right
+		}
+	}
+	return entryOf(ret)
+}
+
+// Returns entry with the least key greater than or equal to given key.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	var ret *node[K, V]
+	for n := m.root; n != nil; {
+		c := m.cmp(key, n.key)
+		if c == 0 {
+			return entryOf(n)
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			ret = n
+			n = n.left
+		}
+	}
+	return entryOf(ret)
+}
+
+// Returns number of keys strictly less than given key.
+func (m *SortedMap[K, V]) Rank(key K) int {
+	rank := 0
+	for n := m.root; n != nil; {
+		switch c := m.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += size(n.left) + 1
+			n = n.right
+		default:
+			return rank + size(n.left)
+		}
+	}
+	return rank
+}
+
+// Returns entry at given position in key order, starting from zero.
+func (m *SortedMap[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= m.Count() {
+		return entryOf[K, V](nil)
+	}
+	n := m.root
+	for {
+		left := size(n.left)
+		switch {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return entryOf(n)
+		}
+	}
+}
+
+// Returns iterator over entries in ascending key order.
+func (m *SortedMap[K, V]) Iterator() types.Iterator[types.Pair[K, V]] {
+	return &pairIterator[K, V]{nodes: newNodeIterator(m.cmp, m.root, false, nil, nil)}
+}
+
+// Returns iterator over entries in descending key order.
+func (m *SortedMap[K, V]) ReverseIterator() types.Iterator[types.Pair[K, V]] {
+	return &pairIterator[K, V]{nodes: newNodeIterator(m.cmp, m.root, true, nil, nil)}
+}
+
+// Returns iterator over entries with keys in [from, to) range in ascending
+// key order.
+func (m *SortedMap[K, V]) Range(from, to K) types.Iterator[types.Pair[K, V]] {
+	return &pairIterator[K, V]{nodes: m.rangeNodes(from, to)}
+}
+
+// All returns a sequence over entries in ascending key order.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := newNodeIterator(m.cmp, m.root, false, nil, nil)
+		for it.hasNext() {
+			n := it.next()
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a sequence over entries in descending key order.
+func (m *SortedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		it := newNodeIterator(m.cmp, m.root, true, nil, nil)
+		for it.hasNext() {
+			n := it.next()
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+func (m *SortedMap[K, V]) rangeNodes(from, to K) *nodeIterator[K, V] {
+	outside := func(key K) bool {
+		return m.cmp(key, to) >= 0
+	}
+	return newNodeIterator(m.cmp, m.root, false, &from, outside)
+}
+
+func (m *SortedMap[K, V]) find(key K) *node[K, V] {
+	if m == nil {
+		return nil
+	}
+	for n := m.root; n != nil; {
+		c := m.cmp(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+func entryOf[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var (
+			k K
+			v V
+		)
+		return k, v, false
+	}
+	return n.key, n.value, true
+}
@@ -0,0 +1,201 @@
+package sortedmap
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/igumus/gdsa/types"
+	"github.com/stretchr/testify/require"
+)
+
+var _ types.Collection[int] = (*SortedSet[int])(nil)
+
+// Checks ordering, size bookkeeping and weight balance of every node.
+func requireValidTree[K, V any](t *testing.T, cmp func(K, K) int, n *node[K, V]) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	sl, sr := size(n.left), size(n.right)
+	require.Equal(t, sl+sr+1, n.size)
+	if sl+sr > 1 {
+		require.LessOrEqual(t, sl, delta*sr)
+		require.LessOrEqual(t, sr, delta*sl)
+	}
+	if n.left != nil {
+		require.Negative(t, cmp(maxNode(n.left).key, n.key))
+	}
+	if n.right != nil {
+		require.Positive(t, cmp(minNode(n.right).key, n.key))
+	}
+	requireValidTree(t, cmp, n.left)
+	requireValidTree(t, cmp, n.right)
+}
+
+func keysOf[V any](it types.Iterator[types.Pair[int, V]]) []int {
+	ret := make([]int, 0)
+	for it.HasNext() {
+		ret = append(ret, it.Next().Key)
+	}
+	return ret
+}
+
+func TestSortedMapAssocDissoc(t *testing.T) {
+	m := NewOrdered[int, string]()
+	require.True(t, m.IsEmpty())
+	other := m.Assoc(2, "b").Assoc(1, "a").Assoc(3, "c")
+	require.True(t, m.IsEmpty())
+	require.Equal(t, 3, other.Count())
+	value, ok := other.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "a", value)
+
+	replaced := other.Assoc(1, "A")
+	require.Equal(t, 3, replaced.Count())
+	value, _ = other.Get(1)
+	require.Equal(t, "a", value)
+
+	removed := replaced.Dissoc(2)
+	require.Equal(t, 2, removed.Count())
+	require.False(t, removed.Contains(2))
+	require.True(t, replaced.Contains(2))
+	require.Equal(t, removed, removed.Dissoc(42))
+}
+
+func TestSortedMapQueries(t *testing.T) {
+	m := NewOrdered[int, int]()
+	for i := 0; i < 100; i += 10 {
+		m = m.Assoc(i, i*i)
+	}
+
+	k, _, ok := m.Floor(35)
+	require.True(t, ok)
+	require.Equal(t, 30, k)
+	k, _, _ = m.Floor(30)
+	require.Equal(t, 30, k)
+	_, _, ok = m.Floor(-1)
+	require.False(t, ok)
+
+	k, _, ok = m.Ceiling(35)
+	require.True(t, ok)
+	require.Equal(t, 40, k)
+	_, _, ok = m.Ceiling(91)
+	require.False(t, ok)
+
+	k, _, _ = m.First()
+	require.Equal(t, 0, k)
+	k, _, _ = m.Last()
+	require.Equal(t, 90, k)
+
+	require.Equal(t, 0, m.Rank(0))
+	require.Equal(t, 4, m.Rank(35))
+	require.Equal(t, 4, m.Rank(40))
+	require.Equal(t, 10, m.Rank(1000))
+	k, v, ok := m.Select(4)
+	require.True(t, ok)
+	require.Equal(t, 40, k)
+	require.Equal(t, 1600, v)
+	_, _, ok = m.Select(10)
+	require.False(t, ok)
+
+	require.Equal(t, []int{20, 30, 40}, keysOf(m.Range(15, 50)))
+	require.Equal(t, []int{}, keysOf(m.Range(50, 50)))
+	require.Equal(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, keysOf(m.Iterator()))
+	require.Equal(t, []int{90, 80, 70, 60, 50, 40, 30, 20, 10, 0}, keysOf(m.ReverseIterator()))
+}
+
+func TestSortedMapComparator(t *testing.T) {
+	// orders strings by length first, then lexicographically
+	m := New[string, int](func(a, b string) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		return strings.Compare(a, b)
+	})
+	for i, k := range []string{"ccc", "a", "bb", "aa", "b"} {
+		m = m.Assoc(k, i)
+	}
+	keys := make([]string, 0)
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	require.Equal(t, []string{"a", "b", "aa", "bb", "ccc"}, keys)
+}
+
+func TestSortedSet(t *testing.T) {
+	s := NewOrderedSet[int]()
+	for _, v := range []int{5, 3, 8, 1, 9, 3} {
+		s = s.Add(v)
+	}
+	require.Equal(t, 5, s.Count())
+	require.True(t, s.ContainsValue(8))
+	require.False(t, s.ContainsValue(4))
+
+	require.Equal(t, []int{1, 3, 5, 8, 9}, slices.Collect(s.All()))
+	require.Equal(t, []int{9, 8, 5, 3, 1}, slices.Collect(s.Backward()))
+	require.Equal(t, []int{3, 5}, slices.Collect(types.Seq(s.Range(2, 8))))
+	require.Equal(t, []int{9, 8, 5, 3, 1}, slices.Collect(types.Seq(s.ReverseIterator())))
+
+	v, _ := s.Floor(4)
+	require.Equal(t, 3, v)
+	v, _ = s.Ceiling(4)
+	require.Equal(t, 5, v)
+	v, _ = s.Select(s.Rank(8))
+	require.Equal(t, 8, v)
+
+	other := s.Remove(5)
+	require.Equal(t, 4, other.Count())
+	require.True(t, s.ContainsValue(5))
+	require.Equal(t, other, other.Remove(5))
+}
+
+// Applies random operations to both sorted map and builtin map, keeping
+// every intermediate version around to check persistence and invariants.
+func TestSortedMapProperties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	type version struct {
+		coll     *SortedMap[int, int]
+		expected map[int]int
+	}
+	history := []version{{coll: NewOrdered[int, int](), expected: map[int]int{}}}
+
+	for step := 0; step < 1000; step++ {
+		// mostly grow the latest version so trees get deep enough
+		current := history[len(history)-1]
+		if rnd.Intn(5) == 0 {
+			current = history[rnd.Intn(len(history))]
+		}
+		expected := make(map[int]int, len(current.expected))
+		for k, v := range current.expected {
+			expected[k] = v
+		}
+		coll := current.coll
+		k := rnd.Intn(1000)
+		if rnd.Intn(3) > 0 {
+			coll = coll.Assoc(k, step)
+			expected[k] = step
+		} else {
+			coll = coll.Dissoc(k)
+			delete(expected, k)
+		}
+		history = append(history, version{coll: coll, expected: expected})
+	}
+
+	for _, v := range history {
+		requireValidTree(t, v.coll.cmp, v.coll.root)
+		keys := make([]int, 0, len(v.expected))
+		for k := range v.expected {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		require.Equal(t, keys, keysOf(v.coll.Iterator()))
+		for i, k := range keys {
+			require.Equal(t, i, v.coll.Rank(k))
+			value, ok := v.coll.Get(k)
+			require.True(t, ok)
+			require.Equal(t, v.expected[k], value)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package matrix
+
+import (
+	"math"
+
+	"github.com/igumus/gdsa/vector"
+)
+
+// Rounds value by precision policy of package vector.
+func rounded(val float64) float64 {
+	return vector.Round(val)
+}
+
+// Reports whether value is zero by precision policy of package vector, so
+// noise below the rounded digits does not count.
+func isZero(val float64) bool {
+	return rounded(val) == 0
+}
+
+// Rounds every entry of given matrix.
+func roundAll(m []float64) {
+	for i, v := range m {
+		m[i] = rounded(v)
+	}
+}
+
+// Multiplies n by n row-major matrices a and b into ret.
+func multiply(n int, ret, a, b []float64) {
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			var sum float64
+			for k := 0; k < n; k++ {
+				sum += a[r*n+k] * b[k*n+c]
+			}
+			ret[r*n+c] = sum
+		}
+	}
+	roundAll(ret)
+}
+
+// Transposes n by n row-major matrix m into ret.
+func transpose(n int, ret, m []float64) {
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			ret[c*n+r] = m[r*n+c]
+		}
+	}
+}
+
+// Calculates determinant of n by n row-major matrix using Gaussian
+// elimination with partial pivoting.
+func determinant(n int, m []float64) float64 {
+	a := append([]float64(nil), m...)
+	det := 1.0
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r*n+col]) > math.Abs(a[pivot*n+col]) {
+				pivot = r
+			}
+		}
+		if isZero(a[pivot*n+col]) {
+			return 0
+		}
+		if pivot != col {
+			swapRows(n, a, pivot, col)
+			det = -det
+		}
+		det *= a[col*n+col]
+		for r := col + 1; r < n; r++ {
+			f := a[r*n+col] / a[col*n+col]
+			for c := col; c < n; c++ {
+				a[r*n+c] -= f * a[col*n+c]
+			}
+		}
+	}
+	return rounded(det)
+}
+
+// Inverts n by n row-major matrix m into ret using Gauss-Jordan elimination
+// with partial pivoting. Reports false when matrix is singular.
+func inverse(n int, ret, m []float64) bool {
+	a := append([]float64(nil), m...)
+	for i := range ret {
+		ret[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		ret[i*n+i] = 1
+	}
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r*n+col]) > math.Abs(a[pivot*n+col]) {
+				pivot = r
+			}
+		}
+		if isZero(a[pivot*n+col]) {
+			return false
+		}
+		swapRows(n, a, pivot, col)
+		swapRows(n, ret, pivot, col)
+		p := a[col*n+col]
+		for c := 0; c < n; c++ {
+			a[col*n+c] /= p
+			ret[col*n+c] /= p
+		}
+		for r := 0; r < n; r++ {
+			if r == col {
+				continue
+			}
+			f := a[r*n+col]
+			for c := 0; c < n; c++ {
+				a[r*n+c] -= f * a[col*n+c]
+				ret[r*n+c] -= f * ret[col*n+c]
+			}
+		}
+	}
+	roundAll(ret)
+	return true
+}
+
+func swapRows(n int, m []float64, i, j int) {
+	if i == j {
+		return
+	}
+	for c := 0; c < n; c++ {
+		m[i*n+c], m[j*n+c] = m[j*n+c], m[i*n+c]
+	}
+}
+
+// Reports whether entries of given matrices differ by nothing but noise
+// below the precision policy of package vector.
+func equal(a, b []float64) bool {
+	for i := range a {
+		if !isZero(a[i] - b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether entries of given matrices differ by at most eps.
+func approxEqual(a, b []float64, eps float64) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > eps {
+			return false
+		}
+	}
+	return true
+}
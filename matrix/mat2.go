@@ -0,0 +1,97 @@
+// Package matrix provides square matrices for linear and affine transforms
+// of vectors from package vector. Matrices are small row-major arrays with
+// value semantics, so their methods return new matrices. Like vectors,
+// results are rounded by the precision policy of package vector.
+//
+// Mat3 and Mat4 treat vectors as points in homogeneous coordinates, so their
+// Transform reports points mapped to infinity. Mat2 is purely linear, has no
+// w coordinate to divide by, and so its Transform always succeeds and
+// returns the vector alone.
+package matrix
+
+import (
+	"math"
+
+	"github.com/igumus/gdsa/vector"
+)
+
+// Mat2 is a 2x2 matrix for linear transforms of 2D vectors.
+type Mat2 [4]float64
+
+// Creates identity matrix.
+func Identity2() Mat2 {
+	return Mat2{1, 0, 0, 1}
+}
+
+// Creates matrix rotating counterclockwise by theta radians.
+func Rotation2(theta float64) Mat2 {
+	cos, sin := rounded(math.Cos(theta)), rounded(math.Sin(theta))
+	return Mat2{cos, -sin, sin, cos}
+}
+
+// Creates matrix scaling by sx and sy along the axes.
+func Scaling2(sx, sy float64) Mat2 {
+	return Mat2{sx, 0, 0, sy}
+}
+
+// Creates matrix shearing x by shx times y and y by shy times x.
+func Shear2(shx, shy float64) Mat2 {
+	return Mat2{1, shx, shy, 1}
+}
+
+// Returns entry at given row and column.
+func (m Mat2) At(row, col int) float64 {
+	return m[row*2+col]
+}
+
+// Calculates m * o, which applies o first when transforming vectors.
+func (m Mat2) Multiply(o Mat2) Mat2 {
+	var ret Mat2
+	multiply(2, ret[:], m[:], o[:])
+	return ret
+}
+
+// Returns transpose of the matrix.
+func (m Mat2) Transpose() Mat2 {
+	var ret Mat2
+	transpose(2, ret[:], m[:])
+	return ret
+}
+
+// Calculates determinant of the matrix.
+func (m Mat2) Determinant() float64 {
+	return determinant(2, m[:])
+}
+
+// Calculates inverse of the matrix. Reports false when matrix is singular.
+func (m Mat2) Inverse() (Mat2, bool) {
+	var ret Mat2
+	ok := inverse(2, ret[:], m[:])
+	return ret, ok
+}
+
+// Checks entries of both matrices are equal, ignoring differences the
+// precision policy of package vector rounds away.
+func (m Mat2) Equal(o Mat2) bool {
+	return equal(m[:], o[:])
+}
+
+// Checks entries of both matrices differ by at most eps.
+func (m Mat2) ApproxEqual(o Mat2, eps float64) bool {
+	return approxEqual(m[:], o[:], eps)
+}
+
+// Transforms given vector without mutating it.
+func (m Mat2) Transform(v *vector.Vector) *vector.Vector {
+	x, y := v.X(), v.Y()
+	return vector.CreateWithPoints(rounded(m[0]*x+m[1]*y), rounded(m[2]*x+m[3]*y))
+}
+
+// Transforms given vectors without mutating them.
+func (m Mat2) TransformAll(vs []*vector.Vector) []*vector.Vector {
+	ret := make([]*vector.Vector, len(vs))
+	for i, v := range vs {
+		ret[i] = m.Transform(v)
+	}
+	return ret
+}
@@ -0,0 +1,111 @@
+package matrix
+
+import (
+	"math"
+
+	"github.com/igumus/gdsa/vector"
+)
+
+// Mat3 is a 3x3 matrix for affine transforms of 2D vectors in homogeneous
+// coordinates.
+type Mat3 [9]float64
+
+// Creates identity matrix.
+func Identity3() Mat3 {
+	return Mat3{1, 0, 0, 0, 1, 0, 0, 0, 1}
+}
+
+// Creates matrix translating by tx and ty.
+func Translation3(tx, ty float64) Mat3 {
+	return Mat3{1, 0, tx, 0, 1, ty, 0, 0, 1}
+}
+
+// Creates matrix rotating counterclockwise by theta radians about the
+// origin.
+func Rotation3(theta float64) Mat3 {
+	cos, sin := rounded(math.Cos(theta)), rounded(math.Sin(theta))
+	return Mat3{cos, -sin, 0, sin, cos, 0, 0, 0, 1}
+}
+
+// Creates matrix scaling by sx and sy along the axes.
+func Scaling3(sx, sy float64) Mat3 {
+	return Mat3{sx, 0, 0, 0, sy, 0, 0, 0, 1}
+}
+
+// Creates matrix shearing x by shx times y and y by shy times x.
+func Shear3(shx, shy float64) Mat3 {
+	return Mat3{1, shx, 0, shy, 1, 0, 0, 0, 1}
+}
+
+// Returns entry at given row and column.
+func (m Mat3) At(row, col int) float64 {
+	return m[row*3+col]
+}
+
+// Calculates m * o, which applies o first when transforming vectors.
+func (m Mat3) Multiply(o Mat3) Mat3 {
+	var ret Mat3
+	multiply(3, ret[:], m[:], o[:])
+	return ret
+}
+
+// Returns transpose of the matrix.
+func (m Mat3) Transpose() Mat3 {
+	var ret Mat3
+	transpose(3, ret[:], m[:])
+	return ret
+}
+
+// Calculates determinant of the matrix.
+func (m Mat3) Determinant() float64 {
+	return determinant(3, m[:])
+}
+
+// Calculates inverse of the matrix. Reports false when matrix is singular.
+func (m Mat3) Inverse() (Mat3, bool) {
+	var ret Mat3
+	ok := inverse(3, ret[:], m[:])
+	return ret, ok
+}
+
+// Checks entries of both matrices are equal, ignoring differences the
+// precision policy of package vector rounds away.
+func (m Mat3) Equal(o Mat3) bool {
+	return equal(m[:], o[:])
+}
+
+// Checks entries of both matrices differ by at most eps.
+func (m Mat3) ApproxEqual(o Mat3, eps float64) bool {
+	return approxEqual(m[:], o[:], eps)
+}
+
+// Transforms given vector, treated as a point, without mutating it.
+// Projective results are divided by their w coordinate. Reports false when
+// the point is mapped to infinity, as w is zero; the vector then holds the
+// undivided coordinates, giving direction of the point.
+func (m Mat3) Transform(v *vector.Vector) (*vector.Vector, bool) {
+	x, y := v.X(), v.Y()
+	tx := m[0]*x + m[1]*y + m[2]
+	ty := m[3]*x + m[4]*y + m[5]
+	w := m[6]*x + m[7]*y + m[8]
+	if isZero(w) {
+		return vector.CreateWithPoints(rounded(tx), rounded(ty)), false
+	}
+	if w != 1 {
+		tx, ty = tx/w, ty/w
+	}
+	return vector.CreateWithPoints(rounded(tx), rounded(ty)), true
+}
+
+// Transforms given vectors without mutating them. Reports false when any of
+// them is mapped to infinity, as Transform does.
+func (m Mat3) TransformAll(vs []*vector.Vector) ([]*vector.Vector, bool) {
+	ret := make([]*vector.Vector, len(vs))
+	finite := true
+	for i, v := range vs {
+		var ok bool
+		ret[i], ok = m.Transform(v)
+		finite = finite && ok
+	}
+	return ret, finite
+}
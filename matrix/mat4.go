@@ -0,0 +1,147 @@
+package matrix
+
+import (
+	"math"
+
+	"github.com/igumus/gdsa/vector"
+)
+
+// Mat4 is a 4x4 matrix for affine transforms of 3D vectors in homogeneous
+// coordinates.
+type Mat4 [16]float64
+
+// Creates identity matrix.
+func Identity4() Mat4 {
+	return Mat4{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Creates matrix translating by tx, ty and tz.
+func Translation4(tx, ty, tz float64) Mat4 {
+	return Mat4{
+		1, 0, 0, tx,
+		0, 1, 0, ty,
+		0, 0, 1, tz,
+		0, 0, 0, 1,
+	}
+}
+
+// Creates matrix rotating by theta radians about given axis through the
+// origin, counterclockwise when looking from the tip of the axis. Zero axis
+// has no direction to rotate about, so identity matrix is returned for it.
+func Rotation4(axis *vector.Vector3, theta float64) Mat4 {
+	if isZero(axis.Length()) {
+		return Identity4()
+	}
+	unit := vector.Normalize3(axis)
+	x, y, z := unit.X(), unit.Y(), unit.Z()
+	cos, sin := math.Cos(theta), math.Sin(theta)
+	t := 1 - cos
+	ret := Mat4{
+		t*x*x + cos, t*x*y - sin*z, t*x*z + sin*y, 0,
+		t*x*y + sin*z, t*y*y + cos, t*y*z - sin*x, 0,
+		t*x*z - sin*y, t*y*z + sin*x, t*z*z + cos, 0,
+		0, 0, 0, 1,
+	}
+	roundAll(ret[:])
+	return ret
+}
+
+// Creates matrix scaling by sx, sy and sz along the axes.
+func Scaling4(sx, sy, sz float64) Mat4 {
+	return Mat4{
+		sx, 0, 0, 0,
+		0, sy, 0, 0,
+		0, 0, sz, 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Creates matrix shearing each coordinate by the others: xy is the factor
+// x is sheared by y, and so on.
+func Shear4(xy, xz, yx, yz, zx, zy float64) Mat4 {
+	return Mat4{
+		1, xy, xz, 0,
+		yx, 1, yz, 0,
+		zx, zy, 1, 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Returns entry at given row and column.
+func (m Mat4) At(row, col int) float64 {
+	return m[row*4+col]
+}
+
+// Calculates m * o, which applies o first when transforming vectors.
+func (m Mat4) Multiply(o Mat4) Mat4 {
+	var ret Mat4
+	multiply(4, ret[:], m[:], o[:])
+	return ret
+}
+
+// Returns transpose of the matrix.
+func (m Mat4) Transpose() Mat4 {
+	var ret Mat4
+	transpose(4, ret[:], m[:])
+	return ret
+}
+
+// Calculates determinant of the matrix.
+func (m Mat4) Determinant() float64 {
+	return determinant(4, m[:])
+}
+
+// Calculates inverse of the matrix. Reports false when matrix is singular.
+func (m Mat4) Inverse() (Mat4, bool) {
+	var ret Mat4
+	ok := inverse(4, ret[:], m[:])
+	return ret, ok
+}
+
+// Checks entries of both matrices are equal, ignoring differences the
+// precision policy of package vector rounds away.
+func (m Mat4) Equal(o Mat4) bool {
+	return equal(m[:], o[:])
+}
+
+// Checks entries of both matrices differ by at most eps.
+func (m Mat4) ApproxEqual(o Mat4, eps float64) bool {
+	return approxEqual(m[:], o[:], eps)
+}
+
+// Transforms given vector, treated as a point, without mutating it.
+// Projective results are divided by their w coordinate. Reports false when
+// the point is mapped to infinity, as w is zero; the vector then holds the
+// undivided coordinates, giving direction of the point.
+func (m Mat4) Transform(v *vector.Vector3) (*vector.Vector3, bool) {
+	x, y, z := v.X(), v.Y(), v.Z()
+	tx := m[0]*x + m[1]*y + m[2]*z + m[3]
+	ty := m[4]*x + m[5]*y + m[6]*z + m[7]
+	tz := m[8]*x + m[9]*y + m[10]*z + m[11]
+	w := m[12]*x + m[13]*y + m[14]*z + m[15]
+	if isZero(w) {
+		return vector.CreateVector3(rounded(tx), rounded(ty), rounded(tz)), false
+	}
+	if w != 1 {
+		tx, ty, tz = tx/w, ty/w, tz/w
+	}
+	return vector.CreateVector3(rounded(tx), rounded(ty), rounded(tz)), true
+}
+
+// Transforms given vectors without mutating them. Reports false when any of
+// them is mapped to infinity, as Transform does.
+func (m Mat4) TransformAll(vs []*vector.Vector3) ([]*vector.Vector3, bool) {
+	ret := make([]*vector.Vector3, len(vs))
+	finite := true
+	for i, v := range vs {
+		var ok bool
+		ret[i], ok = m.Transform(v)
+		finite = finite && ok
+	}
+	return ret, finite
+}
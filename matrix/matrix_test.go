@@ -0,0 +1,177 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/igumus/gdsa/vector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixMat2(t *testing.T) {
+	m := Mat2{1, 2, 3, 4}
+	assert.Equal(t, -2.0, m.Determinant())
+	assert.Equal(t, Mat2{1, 3, 2, 4}, m.Transpose())
+	assert.Equal(t, 3.0, m.At(1, 0))
+
+	inv, ok := m.Inverse()
+	assert.True(t, ok)
+	assert.Equal(t, Mat2{-2, 1, 1.5, -0.5}, inv)
+	assert.True(t, m.Multiply(inv).Equal(Identity2()))
+
+	_, ok = Mat2{1, 2, 2, 4}.Inverse()
+	assert.False(t, ok)
+
+	v := vector.CreateWithPoints(1, 0)
+	r := Rotation2(math.Pi / 2).Transform(v)
+	assert.Equal(t, 0.0, r.X())
+	assert.Equal(t, 1.0, r.Y())
+	assert.Equal(t, 1.0, v.X())
+
+	s := Scaling2(2, 3).Transform(vector.CreateWithPoints(1, 1))
+	assert.Equal(t, 2.0, s.X())
+	assert.Equal(t, 3.0, s.Y())
+
+	sh := Shear2(1, 0).Transform(vector.CreateWithPoints(1, 2))
+	assert.Equal(t, 3.0, sh.X())
+	assert.Equal(t, 2.0, sh.Y())
+}
+
+func TestMatrixMat3(t *testing.T) {
+	assert.Equal(t, 1.0, Identity3().Determinant())
+	assert.Equal(t, 6.0, Scaling3(2, 3).Determinant())
+	assert.Equal(t, 0.0, Mat3{1, 2, 3, 4, 5, 6, 7, 8, 9}.Determinant())
+
+	// rotate first, then translate
+	m := Translation3(1, 2).Multiply(Rotation3(math.Pi / 2))
+	p := transform3(t, m, vector.CreateWithPoints(1, 0))
+	assert.Equal(t, 1.0, p.X())
+	assert.Equal(t, 3.0, p.Y())
+
+	inv, ok := m.Inverse()
+	assert.True(t, ok)
+	back := transform3(t, inv, p)
+	assert.Equal(t, 1.0, back.X())
+	assert.Equal(t, 0.0, back.Y())
+	assert.True(t, m.Multiply(inv).Equal(Identity3()))
+
+	_, ok = Scaling3(0, 1).Inverse()
+	assert.False(t, ok)
+
+	m = Mat3{1, 2, 3, 4, 5, 6, 7, 8, 10}
+	assert.Equal(t, m, m.Transpose().Transpose())
+	assert.Equal(t, 4.0, m.Transpose().At(0, 1))
+
+	vs := []*vector.Vector{vector.CreateWithPoints(0, 0), vector.CreateWithPoints(1, 1)}
+	ret, ok := Translation3(1, -1).TransformAll(vs)
+	assert.True(t, ok)
+	assert.Len(t, ret, 2)
+	assert.Equal(t, 1.0, ret[0].X())
+	assert.Equal(t, -1.0, ret[0].Y())
+	assert.Equal(t, 2.0, ret[1].X())
+	assert.Equal(t, 0.0, ret[1].Y())
+	assert.Equal(t, 0.0, vs[0].X())
+
+	sh := transform3(t, Shear3(0, 2), vector.CreateWithPoints(1, 1))
+	assert.Equal(t, 1.0, sh.X())
+	assert.Equal(t, 3.0, sh.Y())
+
+	// perspective division, and points mapped to infinity
+	perspective := Mat3{1, 0, 0, 0, 1, 0, 1, 0, 0}
+	divided := transform3(t, perspective, vector.CreateWithPoints(2, 4))
+	assert.Equal(t, 1.0, divided.X())
+	assert.Equal(t, 2.0, divided.Y())
+	direction, ok := perspective.Transform(vector.CreateWithPoints(0, 4))
+	assert.False(t, ok)
+	assert.Equal(t, 4.0, direction.Y())
+	_, ok = perspective.TransformAll([]*vector.Vector{vector.CreateWithPoints(2, 4), vector.CreateWithPoints(0, 4)})
+	assert.False(t, ok)
+	// w within rounding noise of zero is zero too
+	_, ok = perspective.Transform(vector.CreateWithPoints(1e-13, 4))
+	assert.False(t, ok)
+}
+
+func TestMatrixMat4(t *testing.T) {
+	assert.Equal(t, 24.0, Scaling4(2, 3, 4).Determinant())
+	assert.InDelta(t, 1.0, Rotation4(vector.CreateVector3(1, 1, 1), 1).Determinant(), 1e-10)
+
+	assert.Equal(t, Identity4(), Rotation4(vector.CreateVector3(0, 0, 0), 1))
+
+	r := Rotation4(vector.CreateVector3(0, 0, 1), math.Pi/2)
+	p := transform4(t, r, vector.CreateVector3(1, 0, 0))
+	assert.True(t, p.Equal(vector.CreateVector3(0, 1, 0)))
+
+	// matches quaternion rotation
+	axis := vector.CreateVector3(1, 2, 3)
+	v := vector.CreateVector3(4, -1, 2)
+	got, want := transform4(t, Rotation4(axis, 0.7), v), vector.RotateAround(v, axis, 0.7)
+	assert.InDelta(t, want.X(), got.X(), 1e-10)
+	assert.InDelta(t, want.Y(), got.Y(), 1e-10)
+	assert.InDelta(t, want.Z(), got.Z(), 1e-10)
+
+	m := Translation4(1, 2, 3).Multiply(Scaling4(2, 2, 2))
+	p = transform4(t, m, vector.CreateVector3(1, 1, 1))
+	assert.True(t, p.Equal(vector.CreateVector3(3, 4, 5)))
+
+	inv, ok := m.Inverse()
+	assert.True(t, ok)
+	assert.True(t, transform4(t, inv, p).Equal(vector.CreateVector3(1, 1, 1)))
+	assert.True(t, inv.Multiply(m).Equal(Identity4()))
+	assert.True(t, r.Transpose().Equal(mustInverse(t, r)))
+
+	_, ok = Shear4(1, 0, 1, 0, 0, 0).Inverse()
+	assert.False(t, ok)
+
+	sh := transform4(t, Shear4(1, 0, 0, 0, 0, 0), vector.CreateVector3(1, 2, 3))
+	assert.True(t, sh.Equal(vector.CreateVector3(3, 2, 3)))
+
+	ret, ok := Translation4(0, 0, 1).TransformAll([]*vector.Vector3{vector.CreateVector3(0, 0, 0)})
+	assert.True(t, ok)
+	assert.True(t, ret[0].Equal(vector.CreateVector3(0, 0, 1)))
+
+	// projection onto z = 1 plane maps points with z = 0 to infinity
+	projection := Mat4{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 1, 0}
+	assert.True(t, transform4(t, projection, vector.CreateVector3(2, 4, 2)).Equal(vector.CreateVector3(1, 2, 1)))
+	_, ok = projection.Transform(vector.CreateVector3(2, 4, 0))
+	assert.False(t, ok)
+}
+
+func TestMatrixPrecision(t *testing.T) {
+	noisy := Identity3()
+	noisy[0] += 1e-13
+	assert.True(t, noisy.Equal(Identity3()))
+	assert.True(t, noisy.ApproxEqual(Identity3(), 1e-12))
+	assert.False(t, noisy.ApproxEqual(Identity3(), 1e-14))
+
+	// tolerance follows the precision policy
+	previous := vector.SetPrecision(vector.PrecisionNone)
+	defer vector.SetPrecision(previous)
+	assert.False(t, noisy.Equal(Identity3()))
+	_, ok := Mat3{1, 0, 0, 0, 1, 0, 1, 0, 0}.Transform(vector.CreateWithPoints(1e-13, 4))
+	assert.True(t, ok)
+	_, ok = Mat2{1, 0, 0, 1e-13}.Inverse()
+	assert.True(t, ok)
+
+	vector.SetPrecision(vector.PrecisionDigits(4))
+	assert.True(t, Mat2{1, 0, 0, 1.00001}.Equal(Identity2()))
+	_, ok = Mat2{1, 0, 0, 1e-5}.Inverse()
+	assert.False(t, ok)
+}
+
+func transform3(t *testing.T, m Mat3, v *vector.Vector) *vector.Vector {
+	ret, ok := m.Transform(v)
+	assert.True(t, ok)
+	return ret
+}
+
+func transform4(t *testing.T, m Mat4, v *vector.Vector3) *vector.Vector3 {
+	ret, ok := m.Transform(v)
+	assert.True(t, ok)
+	return ret
+}
+
+func mustInverse(t *testing.T, m Mat4) Mat4 {
+	inv, ok := m.Inverse()
+	assert.True(t, ok)
+	return inv
+}
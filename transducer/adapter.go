@@ -0,0 +1,123 @@
+package transducer
+
+import (
+	"github.com/igumus/gdsa/transducer/typed"
+)
+
+type functionReducer[T any] struct {
+	rf      ReducerFunction[T]
+	reduced *Reduced
+	// whether reduced is nested in an enclosing reducing process
+	bound bool
+}
+
+// Adapts reducing function into a typed reducer with untyped accumulator.
+// Init returns nil accumulator. Every fresh instance runs the reducing
+// function with its own Reduced. Errors raised by the reducing function stop
+// the typed reducer; typed protocol cannot report them, so reduce through
+// ReduceE to observe them.
+func FromFunction[T any](rf ReducerFunction[T]) typed.Reducer[any, T] {
+	return &functionReducer[T]{rf: rf, reduced: &Reduced{}}
+}
+
+func (r *functionReducer[T]) Init() any {
+	return nil
+}
+
+func (r *functionReducer[T]) Step(acc any, item T) (any, bool) {
+	acc = r.rf(acc, r.reduced, item)
+	return acc, r.reduced.done()
+}
+
+func (r *functionReducer[T]) Complete(acc any) any {
+	return r.rf(acc, r.reduced)
+}
+
+func (r *functionReducer[T]) Fresh() typed.Reducer[any, T] {
+	if r.bound {
+		return r
+	}
+	return &functionReducer[T]{rf: r.rf, reduced: &Reduced{}}
+}
+
+// Converts untyped accumulator to Acc, mapping nil to zero accumulator.
+func accumulator[Acc any](acc any) Acc {
+	if acc == nil {
+		var ret Acc
+		return ret
+	}
+	return acc.(Acc)
+}
+
+// Returns reducing function running a typed reducer, which fresh creates
+// once per reducing process and which is kept in its Reduced.
+func runTyped[Acc, T any](fresh func(*Reduced) typed.Reducer[Acc, T]) ReducerFunction[T] {
+	key := &stateKey{}
+	return func(acc any, reduced *Reduced, items ...T) any {
+		instance, ok := loadState[typed.Reducer[Acc, T]](reduced, key)
+		if !ok {
+			instance = fresh(reduced)
+			storeState(reduced, key, instance)
+		}
+		if len(items) == 0 {
+			// completing phase
+			deleteState(reduced, key)
+			return instance.Complete(accumulator[Acc](acc))
+		}
+		if reduced.done() {
+			return acc
+		}
+		// stepping phase
+		ret, stop := instance.Step(accumulator[Acc](acc), items[0])
+		if stop {
+			reduced.Stop()
+		}
+		return ret
+	}
+}
+
+// Adapts reducing function written against the former *bool stop flag
+// into a reducing function. Setting the flag stops the reducing process.
+//
+// Deprecated: take *Reduced and call its Stop method instead.
+func FromStopFlag[T any](rf func(any, *bool, ...T) any) ReducerFunction[T] {
+	return func(acc any, reduced *Reduced, items ...T) any {
+		stopped := reduced.Stopped()
+		acc = rf(acc, &stopped, items...)
+		if stopped {
+			reduced.Stop()
+		}
+		return acc
+	}
+}
+
+// Adapts typed reducer into reducing function. Accumulators passed to the
+// returned function must be of type Acc.
+//
+// A fresh instance of the typed reducer is created for every reducing
+// process and kept in its Reduced, so the returned function can be shared
+// by sequential and concurrent runs.
+func ToFunction[Acc, T any](r typed.Reducer[Acc, T]) ReducerFunction[T] {
+	return runTyped(func(*Reduced) typed.Reducer[Acc, T] {
+		return typed.Fresh(r)
+	})
+}
+
+// Adapts typed transducer into a transducer over reducing functions. Typed
+// steps are built for every reducing process, on top of the reducing
+// function they transform running within the same process.
+func FromTyped[A, B any](xf typed.Transducer[any, A, B]) Transducer[A, B] {
+	return func(rf ReducerFunction[A]) ReducerFunction[B] {
+		return runTyped(func(reduced *Reduced) typed.Reducer[any, B] {
+			return xf(&functionReducer[A]{rf: rf, reduced: reduced.nested(), bound: true})
+		})
+	}
+}
+
+// Adapts transducer over reducing functions into a typed transducer with
+// untyped accumulator.
+func ToTyped[A, B any](xf Transducer[A, B]) typed.Transducer[any, A, B] {
+	return func(r typed.Reducer[any, A]) typed.Reducer[any, B] {
+		return FromFunction(xf(ToFunction(r)))
+	}
+}
@@ -0,0 +1,96 @@
+package transducer
+
+import (
+	"cmp"
+
+	"github.com/igumus/gdsa/transducer/typed"
+	"github.com/igumus/gdsa/types"
+)
+
+// Aggregating reducing functions below are implemented by the typed
+// reducers in package typed. Accumulators passed to them must be of the
+// accumulator type of the typed reducer.
+
+// Reducing function adding items to the accumulator.
+func Sum[T types.Number]() ReducerFunction[T] {
+	return ToFunction(typed.Sum[T]())
+}
+
+// Reducing function multiplying the accumulator by items.
+func Product[T types.Number]() ReducerFunction[T] {
+	return ToFunction(typed.Product[T]())
+}
+
+// Reducing function counting items into an int accumulator.
+func Count[T any]() ReducerFunction[T] {
+	return ToFunction(typed.Count[T]())
+}
+
+// Reducing function selecting the least item. Initial accumulator is
+// returned when there are no items.
+func Min[T cmp.Ordered]() ReducerFunction[T] {
+	return ToFunction(typed.Min[T]())
+}
+
+// Reducing function selecting the greatest item. Initial accumulator is
+// returned when there are no items.
+func Max[T cmp.Ordered]() ReducerFunction[T] {
+	return ToFunction(typed.Max[T]())
+}
+
+// Reducing function selecting the first item with the least key.
+func MinBy[T any, K cmp.Ordered](f Function[T, K]) ReducerFunction[T] {
+	return ToFunction(typed.MinBy(f))
+}
+
+// Reducing function selecting the first item with the greatest key.
+func MaxBy[T any, K cmp.Ordered](f Function[T, K]) ReducerFunction[T] {
+	return ToFunction(typed.MaxBy(f))
+}
+
+// Reducing function calculating arithmetic mean of items into a float64
+// accumulator.
+func Mean[T types.Number]() ReducerFunction[T] {
+	return ToFunction(typed.Mean[T]())
+}
+
+// Reducing function calculating population variance of items into a
+// float64 accumulator.
+func Variance[T types.Number]() ReducerFunction[T] {
+	return ToFunction(typed.Variance[T]())
+}
+
+// Reducing function grouping items into a map[K][]V accumulator by the key
+// given function returns.
+func GroupBy[K comparable, V any](f Function[V, K]) ReducerFunction[V] {
+	return ToFunction(typed.GroupBy(f))
+}
+
+// Reducing function counting occurrences of each item into a map[T]int
+// accumulator.
+func Frequencies[T comparable]() ReducerFunction[T] {
+	return ToFunction(typed.Frequencies[T]())
+}
+
+// Reducing function returning the first item, stopping the reducing
+// process right after it.
+func First[T any]() ReducerFunction[T] {
+	return ToFunction(typed.First[T]())
+}
+
+// Reducing function returning the last item.
+func Last[T any]() ReducerFunction[T] {
+	return ToFunction(typed.Last[T]())
+}
+
+// Reducing function running given reducing functions over the same items
+// in a single pass, accumulating their results into an []any accumulator
+// at the same positions. Initial accumulator holds initial accumulators of
+// the reducing functions at the same positions.
+func Juxt[T any](rfs ...ReducerFunction[T]) ReducerFunction[T] {
+	reducers := make([]typed.Reducer[any, T], len(rfs))
+	for i, rf := range rfs {
+		reducers[i] = FromFunction(rf)
+	}
+	return ToFunction(typed.Juxt(reducers...))
+}
@@ -0,0 +1,113 @@
+package transducer
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"runtime/debug"
+)
+
+// PanicError reports a panic raised by a user function while reducing items
+// received from a channel. Stack holds the stack trace of the panicking
+// goroutine, as the panic is not re-raised.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("transducer: panic: %v", e.Value)
+}
+
+// Returns a sequence over items received from given channel. Sequence ends
+// when channel is closed or context is done, in which case cancelled is set.
+func receive[T any](ctx context.Context, in <-chan T, cancelled *bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				*cancelled = true
+				return
+			case item, ok := <-in:
+				if !ok || !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Converts recovered panic value into an error.
+func recovered(r any, err *error) {
+	if r != nil {
+		*err = &PanicError{Value: r, Stack: debug.Stack()}
+	}
+}
+
+// Applies given transducer to items received from in, sending produced items
+// to the returned channel. Stateful transducers are flushed when in is
+// closed. Once the reducing process stops, e.g. by Take, no more items are
+// received from in, so producers should also watch ctx to avoid blocking.
+//
+// Output channel is closed when processing ends. Error channel then yields
+// at most one error, either ctx.Err() when context is done before in is
+// drained, a *ReduceError when a user function fails, or a *PanicError when
+// a user function panics, and is closed.
+func Chan[A, B any](ctx context.Context, xf Transducer[B, A], in <-chan A) (<-chan B, <-chan error) {
+	out := make(chan B)
+	errs := make(chan error, 1)
+	// set when context is done before in is drained, either while receiving
+	// or while emitting
+	cancelled := false
+	emit := func(acc any, reduced *Reduced, items ...B) any {
+		if len(items) == 0 || reduced.Stopped() {
+			return acc
+		}
+		if ctx.Err() != nil {
+			cancelled = true
+			reduced.Stop()
+			return acc
+		}
+		select {
+		case out <- items[0]:
+		case <-ctx.Done():
+			cancelled = true
+			reduced.Stop()
+		}
+		return acc
+	}
+
+	go func() {
+		var err error
+		defer func() {
+			recovered(recover(), &err)
+			if err != nil {
+				errs <- err
+			}
+			close(out)
+			close(errs)
+		}()
+		_, _, err = reduce(xf(emit), struct{}{}, receive(ctx, in, &cancelled))
+		if cancelled && err == nil {
+			err = ctx.Err()
+		}
+	}()
+	return out, errs
+}
+
+// Reduces items received from in into initial accumulator using reducing
+// function transformed by given transducer, until in is closed or the
+// reducing process stops. When context is done first, accumulated result
+// so far is returned along with ctx.Err(). Failures of user functions are
+// returned as *ReduceError and panics as *PanicError.
+func ChanReduce[R, A, B any](ctx context.Context, xf Transducer[A, B], rf ReducerFunction[A], initial R, in <-chan B) (ret R, err error) {
+	defer func() {
+		recovered(recover(), &err)
+	}()
+	cancelled := false
+	ret, _, err = reduce(xf(rf), initial, receive(ctx, in, &cancelled))
+	if cancelled {
+		err = ctx.Err()
+	}
+	return ret, err
+}
@@ -0,0 +1,96 @@
+package transducer
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/igumus/gdsa/types"
+)
+
+type FunctionE[T, R any] func(T) (R, error)
+type PredicateE[T any] FunctionE[T, bool]
+
+// ReduceError reports an error raised while reducing the item at Index of
+// the source.
+type ReduceError struct {
+	Index int
+	Err   error
+}
+
+func (e *ReduceError) Error() string {
+	return fmt.Sprintf("transducer: item %d: %v", e.Index, e.Err)
+}
+
+func (e *ReduceError) Unwrap() error {
+	return e.Err
+}
+
+// Creates a transducer that transforms a reducing function by applying a
+// failable mapping function to each input. An error stops the reducing
+// process.
+func MapE[A, B any](f FunctionE[B, A]) Transducer[A, B] {
+	return func(rf ReducerFunction[A]) ReducerFunction[B] {
+		return func(acc any, reduced *Reduced, items ...B) any {
+			if len(items) == 0 {
+				return rf(acc, reduced)
+			}
+			if reduced.Stopped() {
+				return acc
+			}
+			item, err := f(items[0])
+			if err != nil {
+				reduced.Fail(err)
+				return acc
+			}
+			return rf(acc, reduced, item)
+		}
+	}
+}
+
+// Creates a transducer that transforms a reducing function by processing
+// only inputs for which the failable predicate is true. An error stops the
+// reducing process.
+func FilterE[A any](f PredicateE[A]) Transducer[A, A] {
+	return func(rf ReducerFunction[A]) ReducerFunction[A] {
+		return func(acc any, reduced *Reduced, items ...A) any {
+			if len(items) == 0 {
+				return rf(acc, reduced)
+			}
+			if reduced.Stopped() {
+				return acc
+			}
+			ok, err := f(items[0])
+			if err != nil {
+				reduced.Fail(err)
+				return acc
+			}
+			if !ok {
+				return acc
+			}
+			return rf(acc, reduced, items[0])
+		}
+	}
+}
+
+// Reduces items of given iterator like Reduce, additionally returning the
+// error which stopped the reducing process as *ReduceError holding index of
+// the offending item. Completion runs even when reducing process fails, so
+// reducing functions can clean up; accumulator is returned as completed.
+func ReduceE[A, T any](rfn ReducerFunction[T], initial A, it types.Iterator[T]) (A, error) {
+	acc, _, err := reduce(rfn, initial, types.Seq(it))
+	return acc, err
+}
+
+// Reduces items of given sequence like ReduceSeq, additionally returning the
+// error which stopped the reducing process, as ReduceE does.
+func ReduceSeqE[A, T any](rfn ReducerFunction[T], initial A, seq iter.Seq[T]) (A, error) {
+	acc, _, err := reduce(rfn, initial, seq)
+	return acc, err
+}
+
+// Transduces items of given iterators like Transduce, additionally returning
+// the error which stopped the reducing process, as ReduceE does. Index of
+// the offending item counts items of all iterators.
+func TransduceE[A, B, R any](xf Transducer[A, B], rf ReducerFunction[A], initial R, its ...types.Iterator[B]) (R, error) {
+	return ReduceSeqE(xf(rf), initial, chain(its))
+}
@@ -1,46 +1,92 @@
+// Package transducer provides transducers over untyped reducing functions.
+// Accumulators are passed as any and asserted back by Reduce, so reducing
+// into an accumulator of the wrong type panics at run time rather than
+// failing to compile. It is kept for reducing functions written against it
+// and for heterogeneous pipelines; package typed provides the type-safe
+// protocol, and the adapters in this package convert between the two.
 package transducer
 
 import (
-	"fmt"
+	"iter"
+	"time"
 
+	"github.com/igumus/gdsa/transducer/typed"
 	"github.com/igumus/gdsa/types"
 )
 
 type Function[T, R any] func(T) R
 type Predicate[T any] Function[T, bool]
-type ReducerFunction[T any] func(any, *bool, ...T) any
+
+// ReducerFunction is a reducing function. Called with an item it steps the
+// reducing process, and called without items it completes it. Reduced is
+// the per-run context, forwarded unchanged to wrapped reducing functions.
+//
+// Reducing functions used to take a *bool stop flag in place of *Reduced;
+// wrap such functions with FromStopFlag to keep using them.
+type ReducerFunction[T any] func(any, *Reduced, ...T) any
 type Transducer[A, B any] func(ReducerFunction[A]) ReducerFunction[B]
 
-func Append[A any](output any, reduced *bool, items ...A) any {
-	if len(items) == 0 || *reduced {
-		return output
-	}
+// Reduces items of given iterator into initial accumulator using reducing
+// function. Errors stop the reducing process silently; use ReduceE to
+// observe them.
+func Reduce[A, T any](rfn ReducerFunction[T], initial A, it types.Iterator[T]) A {
+	return ReduceSeq(rfn, initial, types.Seq(it))
+}
 
-	item := items[0]
-	switch acc := output.(type) {
-	case []A:
-		acc = append(acc, item)
-		return acc
-	case types.List[A]:
-		acc = acc.Add(item)
-		return acc
-	default:
-		fmt.Printf("unknown type for append: %T\n", acc)
-		return acc
+// Reduces items of given sequence into initial accumulator using reducing
+// function. Sequence is no longer pulled once the reducing process stops.
+// Errors stop the reducing process silently; use ReduceSeqE to observe
+// them.
+func ReduceSeq[A, T any](rfn ReducerFunction[T], initial A, seq iter.Seq[T]) A {
+	acc, _, _ := reduce(rfn, initial, seq)
+	return acc
+}
 
+// Reduces items of given sequence, additionally reporting whether the
+// reducing process stopped early and the error which stopped it.
+func reduce[A, T any](rfn ReducerFunction[T], initial A, seq iter.Seq[T]) (A, bool, error) {
+	reduced := &Reduced{}
+	acc := initial
+	index := 0
+	var err error
+	for item := range seq {
+		acc = rfn(acc, reduced, item).(A)
+		if reduced.done() {
+			if cause := reduced.Err(); cause != nil {
+				err = &ReduceError{Index: index, Err: cause}
+			}
+			break
+		}
+		index++
+	}
+	stopped := reduced.done()
+	acc = rfn(acc, reduced).(A)
+	// completion may fail too, reported at the index past the last item
+	if cause := reduced.Err(); cause != nil && err == nil {
+		err = &ReduceError{Index: index, Err: cause}
 	}
+	return acc, stopped, err
 }
 
-// TODO (@igumus): add Transduce function
-// TODO (@igumus): add several reduce function on last parameter.
+// Reduces items of given iterators, one after another, into initial
+// accumulator using reducing function transformed by given transducer.
+// Transducer state is shared across iterators and completion runs once.
+// Errors stop the reducing process silently; use TransduceE to observe them.
+func Transduce[A, B, R any](xf Transducer[A, B], rf ReducerFunction[A], initial R, its ...types.Iterator[B]) R {
+	return ReduceSeq(xf(rf), initial, chain(its))
+}
 
-func Reduce[A, T any](rfn ReducerFunction[T], initial A, it types.Iterator[T]) A {
-	reduced := false
-	acc := initial
-	for it.HasNext() && !reduced {
-		acc = rfn(acc, &reduced, it.Next()).(A)
+// Returns a sequence over the items of given iterators, one after another.
+func chain[T any](its []types.Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, it := range its {
+			for item := range types.Seq(it) {
+				if !yield(item) {
+					return
+				}
+			}
+		}
 	}
-	return rfn(acc, &reduced).(A)
 }
 
 func Combine[A, B, C any](left Transducer[B, C], right Transducer[A, B]) Transducer[A, C] {
@@ -49,108 +95,39 @@ func Combine[A, B, C any](left Transducer[B, C], right Transducer[A, B]) Transdu
 	}
 }
 
+// Built-in transducers below are implemented by the typed protocol in
+// package typed and adapted to reducing functions.
+
 // Creates a transducer that transforms a reducing function by applying a mapping
 // function to each input.
 func Map[A, B any](f Function[B, A]) Transducer[A, B] {
-	return func(rf ReducerFunction[A]) ReducerFunction[B] {
-		return func(acc any, reduced *bool, items ...B) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				item := items[0]
-				value := f(item)
-				acc = rf(acc, reduced, value)
-			}
-			// completing phase
-			return acc
-		}
-	}
+	return FromTyped(typed.Map[any, A, B](f))
 }
 
 // Creates a transducer that transforms a reducing function by applying a
 // predicate to each input and processing only those inputs for which the
 // predicate is true.
 func Filter[A any](f Predicate[A]) Transducer[A, A] {
-	return func(rf ReducerFunction[A]) ReducerFunction[A] {
-		return func(acc any, reduced *bool, items ...A) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				item := items[0]
-				if f(item) {
-					acc = rf(acc, reduced, item)
-				}
-			}
-			// completing phase
-			return acc
-		}
-	}
+	return FromTyped(typed.Filter[any, A](f))
 }
 
 // Creates a transducer that transforms a reducing function such that
 // it only processes n inputs, then the reducing process stops.
 func Take[A any](n int) Transducer[A, A] {
-	return func(rf ReducerFunction[A]) ReducerFunction[A] {
-		taken := 0
-		return func(acc any, reduced *bool, items ...A) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				item := items[0]
-				if taken < n {
-					acc = rf(acc, reduced, item)
-					taken += 1
-				} else {
-					*reduced = true
-				}
-			} else {
-				// completing phase
-				taken = 0
-			}
-			return acc
-		}
-	}
+	return FromTyped(typed.Take[any, A](n))
 }
 
 // Creates a transducer that transforms a reducing function such that
 // it processes inputs as long as the provided predicate returns true.
 // If the predicate returns false, the reducing process stops.
 func TakeWhile[A any](f Predicate[A]) Transducer[A, A] {
-	return func(rf ReducerFunction[A]) ReducerFunction[A] {
-		return func(acc any, reduced *bool, items ...A) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				item := items[0]
-				ret := f(item)
-				if ret {
-					acc = rf(acc, reduced, item)
-				}
-				*reduced = !ret
-			}
-			// completing phase
-			return acc
-		}
-	}
+	return FromTyped(typed.TakeWhile[any, A](f))
 }
 
 // Creates a transducer that transforms a reducing function such that
 // it skips n inputs, then processes the rest of the inputs.
 func Skip[A any](n int) Transducer[A, A] {
-	return func(rf ReducerFunction[A]) ReducerFunction[A] {
-		skipped := 0
-		return func(acc any, reduced *bool, items ...A) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				item := items[0]
-				if skipped < n {
-					skipped += 1
-				} else {
-					acc = rf(acc, reduced, item)
-				}
-			} else {
-				// completing phase
-				skipped = 0
-			}
-			return acc
-		}
-	}
+	return FromTyped(typed.Skip[any, A](n))
 }
 
 // Creates a transducer that transforms a reducing function such that
@@ -158,46 +135,13 @@ func Skip[A any](n int) Transducer[A, A] {
 // Once the predicate returns false, the rest of the inputs are
 // processed.
 func SkipWhile[A any](f Predicate[A]) Transducer[A, A] {
-	return func(rf ReducerFunction[A]) ReducerFunction[A] {
-		drop := true
-		return func(acc any, reduced *bool, items ...A) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				item := items[0]
-				if drop && f(item) {
-					return acc
-				}
-				drop = false
-				acc = rf(acc, reduced, item)
-			} else {
-				// completing phase
-				drop = true
-			}
-			return acc
-		}
-	}
+	return FromTyped(typed.SkipWhile[any, A](f))
 }
 
 // Creates a transducer that transforms a reducing function such that
 // it processes every nth input.
 func EveryNth[A any](n int) Transducer[A, A] {
-	return func(rf ReducerFunction[A]) ReducerFunction[A] {
-		nth := 0
-		return func(acc any, reduced *bool, items ...A) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				if nth%n == 0 {
-					item := items[0]
-					acc = rf(acc, reduced, item)
-				}
-				nth += 1
-			} else {
-				// completing phase
-				nth = 0
-			}
-			return acc
-		}
-	}
+	return FromTyped(typed.EveryNth[any, A](n))
 }
 
 // Creates a transducer that transforms a reducing function that processes
@@ -206,34 +150,7 @@ func EveryNth[A any](n int) Transducer[A, A] {
 // them to the next reducing function when enough inputs have been accrued. Processes
 // any remaining buffered inputs when the reducing process completes.
 func PartitionAll[A any](n int) Transducer[types.Iterator[A], A] {
-	return func(rf ReducerFunction[types.Iterator[A]]) ReducerFunction[A] {
-		var part []A = nil
-		amount := 0
-		return func(acc any, reduced *bool, items ...A) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				if part == nil {
-					part = make([]A, 0)
-				}
-				item := items[0]
-				if amount == n {
-					acc = rf(acc, reduced, types.NewSliceIterator(part))
-					part = make([]A, 0)
-					amount = 0
-				}
-				part = append(part, item)
-				amount += 1
-			} else {
-				// completing phase
-				if len(part) > 0 {
-					acc = rf(acc, reduced, types.NewSliceIterator(part))
-					part = nil
-				}
-				amount = 0
-			}
-			return acc
-		}
-	}
+	return FromTyped(typed.PartitionAll[any, A](n))
 }
 
 // Creates a transducer that transforms a reducing function that processes
@@ -243,31 +160,109 @@ func PartitionAll[A any](n int) Transducer[types.Iterator[A], A] {
 // the partitioning function returns for a given input is different from the value
 // returned for the previous input.
 func PartitionBy[A, B comparable](f Function[A, B]) Transducer[types.Iterator[A], A] {
-	return func(rf ReducerFunction[types.Iterator[A]]) ReducerFunction[A] {
-		part := make([]A, 0)
-		initialized := false
-		var prior B
-		return func(acc any, reduced *bool, items ...A) any {
-			if len(items) > 0 && !*reduced {
-				// stepping phase
-				item := items[0]
-				val := f(item)
-				if !initialized || prior == val {
-					initialized = true
-				} else {
-					acc = rf(acc, reduced, types.NewSliceIterator(part))
-					part = make([]A, 0)
-				}
-				prior = val
-				part = append(part, item)
-			} else {
-				// completing phase
-				if len(part) > 0 {
-					acc = rf(acc, reduced, types.NewSliceIterator(part))
-				}
-				part = nil
-			}
-			return acc
-		}
-	}
+	return FromTyped(typed.PartitionBy[any, A, B](f))
+}
+
+// Creates a transducer that transforms a reducing function that processes
+// iterables of input into a reducing function that processes individual inputs
+// by gathering windows of the given size, starting a new window every step
+// inputs. Incomplete windows are dropped when the reducing process completes.
+func SlidingWindow[A any](size, step int) Transducer[types.Iterator[A], A] {
+	return FromTyped(typed.SlidingWindow[any, A](size, step))
+}
+
+// Creates a transducer that transforms a reducing function that processes
+// iterables of input into a reducing function that processes individual inputs
+// by gathering them into non-overlapping windows of the given duration, based
+// on the timestamp the provided function returns for each input.
+func TumblingWindow[A any](size time.Duration, ts Function[A, time.Time]) Transducer[types.Iterator[A], A] {
+	return FromTyped(typed.TumblingWindow[any](size, ts))
+}
+
+// Creates a transducer that transforms a reducing function that processes
+// iterables of input into a reducing function that processes individual inputs
+// by gathering them into non-overlapping windows spanning the given width of
+// keys the provided function returns for each input.
+func TumblingWindowBy[A any, K types.Number](width K, key Function[A, K]) Transducer[types.Iterator[A], A] {
+	return FromTyped(typed.TumblingWindowBy[any](width, key))
+}
+
+// Creates a transducer that transforms a reducing function that processes
+// iterables of input into a reducing function that processes individual inputs
+// by gathering them into sessions, ending a session when the timestamp the
+// provided function returns for an input is more than gap after the previous one.
+func SessionWindow[A any](gap time.Duration, ts Function[A, time.Time]) Transducer[types.Iterator[A], A] {
+	return FromTyped(typed.SessionWindow[any](gap, ts))
+}
+
+// Creates a transducer that transforms a reducing function such that it
+// skips inputs equal to the previous input.
+func Dedupe[A comparable]() Transducer[A, A] {
+	return FromTyped(typed.Dedupe[any, A]())
+}
+
+// Creates a transducer that transforms a reducing function such that it
+// processes only the first input for every value the provided key function
+// returns. Use typed.WithLimit option to bound the number of remembered keys.
+func Distinct[A any, K comparable](f Function[A, K], opts ...typed.DistinctOption) Transducer[A, A] {
+	return FromTyped(typed.Distinct[any](f, opts...))
+}
+
+// Creates a transducer that transforms a reducing function such that it
+// processes the separator between consecutive inputs.
+func Interpose[A any](sep A) Transducer[A, A] {
+	return FromTyped(typed.Interpose[any](sep))
+}
+
+// Creates a transducer that transforms a reducing function by applying a
+// mapping function to the index and value of each input.
+func MapIndexed[A, B any](f func(int, B) A) Transducer[A, B] {
+	return FromTyped(typed.MapIndexed[any](f))
+}
+
+// Creates a transducer that transforms a reducing function by applying a
+// function to each input and processing only the results for which the
+// function reports true.
+func Keep[A, B any](f func(B) (A, bool)) Transducer[A, B] {
+	return FromTyped(typed.Keep[any](f))
+}
+
+// Creates a transducer that transforms a reducing function by processing
+// only those inputs for which the predicate is false.
+func Remove[A any](f Predicate[A]) Transducer[A, A] {
+	return FromTyped(typed.Remove[any, A](f))
+}
+
+// Creates a transducer that transforms a reducing function that processes
+// individual inputs into a reducing function that processes iterables of
+// input, processing every item of each iterable.
+func Cat[A any]() Transducer[A, types.Iterator[A]] {
+	return FromTyped(typed.Cat[any, A]())
+}
+
+// Creates a transducer that transforms a reducing function by applying a
+// function returning an iterable to each input and processing every item
+// of the results.
+func Mapcat[A, B any](f Function[B, types.Iterator[A]]) Transducer[A, B] {
+	return FromTyped(typed.Mapcat[any](f))
+}
+
+// Creates a transducer that transforms a reducing function by folding
+// inputs into a running state starting from initial and processing the
+// state after each input.
+func Scan[A, B any](f func(A, B) A, initial A) Transducer[A, B] {
+	return FromTyped(typed.Scan[any](f, initial))
+}
+
+// Creates a transducer that transforms a reducing function by replacing
+// inputs found as keys of the provided map with the associated values.
+func Replace[A comparable](replacements map[A]A) Transducer[A, A] {
+	return FromTyped(typed.Replace[any](replacements))
+}
+
+// Creates a transducer that transforms a reducing function such that it
+// processes each input with the provided probability. The random source is
+// seeded with the provided seed for every reducing process.
+func RandomSample[A any](prob float64, seed int64) Transducer[A, A] {
+	return FromTyped(typed.RandomSample[any, A](prob, seed))
 }
@@ -1,12 +1,30 @@
 package transducer
 
 import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/igumus/gdsa/collection/hashmap"
+	"github.com/igumus/gdsa/collection/pvector"
+	"github.com/igumus/gdsa/collection/sortedmap"
+	"github.com/igumus/gdsa/transducer/typed"
 	"github.com/igumus/gdsa/types"
 	"github.com/stretchr/testify/assert"
 )
 
+func into[R, A, B any](t *testing.T, target R, xf Transducer[A, B], its ...types.Iterator[B]) R {
+	ret, err := Into(target, xf, its...)
+	assert.NoError(t, err)
+	return ret
+}
+
 func toArray[T any](it types.Iterator[T]) []T {
 	ret := make([]T, 0)
 	for it.HasNext() {
@@ -133,3 +151,632 @@ func TestFunctionPartitionBy(t *testing.T) {
 	}
 	assert.Equal(t, 5, len(output))
 }
+
+func TestFunctionReduceSeq(t *testing.T) {
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	xf := Take[int](3)(Append[int])
+	output := ReduceSeq(xf, make([]int, 0), seq)
+	assert.Equal(t, []int{0, 1, 2}, output)
+}
+
+func TestFunctionTransduce(t *testing.T) {
+	output := Transduce(Filter(IsOdd), Append[int], make([]int, 0),
+		types.NewFiniteRange(types.WithEnd(5)),
+		types.NewFiniteRange(types.WithStart(10), types.WithEnd(15)))
+	assert.Equal(t, []int{1, 3, 11, 13}, output)
+
+	// transducer state spans all sources
+	output = Transduce(Take[int](3), Append[int], make([]int, 0),
+		types.NewSliceIterator([]int{1, 2}),
+		types.NewSliceIterator([]int{3, 4}))
+	assert.Equal(t, []int{1, 2, 3}, output)
+}
+
+func TestFunctionInto(t *testing.T) {
+	coll := func() types.Iterator[int] {
+		return types.NewFiniteRange(types.WithEnd(5))
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, into(t, []int{}, Map(IntIncrementer), coll()))
+	assert.Equal(t, "01234", into(t, "", Map(IntStringfy), coll()))
+	assert.Equal(t, "abcdeabc", into(t, "", Map(func(i int) rune { return 'a' + rune(i) }), coll(), types.NewFiniteRange(types.WithEnd(3))))
+
+	toPair := Map(func(i int) types.Pair[string, int] {
+		return types.Pair[string, int]{Key: IntStringfy(i), Value: i * i}
+	})
+	assert.Equal(t, map[string]int{"0": 0, "1": 1, "2": 4, "3": 9, "4": 16}, into(t, map[string]int{}, toPair, coll()))
+	var nilMap map[string]int
+	assert.Equal(t, 5, len(into(t, nilMap, toPair, coll())))
+}
+
+func TestFunctionSequence(t *testing.T) {
+	consumed := 0
+	source := types.FromSeq(func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			consumed++
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	defer source.Stop()
+	it := Sequence(Combine(Filter(IsEven), Map(IntIncrementer)), source)
+	assert.True(t, it.HasNext())
+	assert.Equal(t, 1, it.Next())
+	assert.Equal(t, 1, consumed)
+	assert.Equal(t, 3, it.Next())
+	assert.Equal(t, 3, consumed)
+
+	parts := Sequence(PartitionAll[int](2), types.NewFiniteRange(types.WithEnd(5)))
+	expected := [][]int{{0, 1}, {2, 3}, {4}}
+	for _, part := range expected {
+		assert.True(t, parts.HasNext())
+		assert.Equal(t, part, toArray(parts.Next()))
+	}
+	assert.False(t, parts.HasNext())
+
+	taken := Sequence(Take[int](3), types.NewInfiniteRange())
+	assert.Equal(t, []int{0, 1, 2}, toArray(taken))
+	assert.NoError(t, taken.Close())
+
+	// closing abandoned sequence runs completion once
+	completions := 0
+	counting := func(rf ReducerFunction[int]) ReducerFunction[int] {
+		return func(acc any, reduced *Reduced, items ...int) any {
+			if len(items) == 0 {
+				completions++
+			}
+			return rf(acc, reduced, items...)
+		}
+	}
+	abandoned := Sequence(Combine(counting, PartitionAll[int](2)), types.NewInfiniteRange())
+	assert.Equal(t, []int{0, 1}, toArray(abandoned.Next()))
+	assert.True(t, abandoned.HasNext())
+	assert.NoError(t, abandoned.Close())
+	assert.NoError(t, abandoned.Close())
+	assert.Equal(t, 1, completions)
+	assert.False(t, abandoned.HasNext())
+
+	failing := Sequence(MapE(strconv.Atoi), types.NewSliceIterator([]string{"1", "x", "3"}))
+	assert.Equal(t, []int{1}, toArray(failing))
+	assert.Error(t, failing.Err())
+	assert.Error(t, failing.Close())
+}
+
+func TestFunctionTypedAdapters(t *testing.T) {
+	// typed reducer used as reducing function
+	rf := ToFunction(typed.Append[int]())
+	output := Reduce(Map(IntIncrementer)(rf), []int{}, types.NewFiniteRange(types.WithEnd(3)))
+	assert.Equal(t, []int{1, 2, 3}, output)
+
+	// reducing function used as typed reducer
+	xf := ToTyped(Filter(IsEven))
+	assert.Equal(t, []int{0, 2}, typed.Reduce(xf(FromFunction(Append[int])), any([]int{}), types.NewFiniteRange(types.WithEnd(4))))
+
+	// reducing function written against the former stop flag
+	legacy := FromStopFlag(func(acc any, stopped *bool, items ...int) any {
+		if len(items) == 0 || *stopped {
+			return acc
+		}
+		*stopped = items[0] >= 2
+		return append(acc.([]int), items[0])
+	})
+	assert.Equal(t, []int{1, 2}, Reduce(Map(IntIncrementer)(legacy), []int{}, types.NewFiniteRange(types.WithEnd(5))))
+}
+
+func TestFunctionReuse(t *testing.T) {
+	xf := Combine(Skip[int](2), Combine(EveryNth[int](2), Take[int](6)))(Append[int])
+	expected := []int{2, 4, 6, 8, 10, 12}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, expected, Reduce(xf, []int{}, types.NewInfiniteRange()))
+	}
+
+	joined := Map(IntStringfy)(StringAppend(","))
+	assert.Equal(t, "0,1,2,", Reduce(joined, "", types.NewFiniteRange(types.WithEnd(3))))
+	assert.Equal(t, "0,1,", Reduce(joined, "", types.NewFiniteRange(types.WithEnd(2))))
+	assert.Equal(t, "n:0,", Reduce(joined, "n:", types.NewFiniteRange(types.WithEnd(1))))
+
+	// driving reducing function by hand keeps state in given Reduced
+	taken := Take[int](2)(Append[int])
+	first, second := &Reduced{}, &Reduced{}
+	acc1, acc2 := taken([]int{}, first, 1), taken([]int{}, second, 10)
+	acc1 = taken(acc1, first, 2)
+	assert.True(t, first.Stopped())
+	assert.False(t, second.Stopped())
+	acc2 = taken(acc2, second, 20)
+	acc1 = taken(acc1, first, 3)
+	assert.Equal(t, []int{1, 2}, taken(acc1, first))
+	assert.Equal(t, []int{10, 20}, taken(acc2, second))
+	assert.Empty(t, first.run.states)
+}
+
+func TestFunctionConcurrentReuse(t *testing.T) {
+	partitions := PartitionBy(func(i int) int { return i / 3 })(Append[types.Iterator[int]])
+	xf := Combine(Skip[int](1), Combine(Take[int](9), PartitionAll[int](2)))(Append[types.Iterator[int]])
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				parts := Reduce(xf, []types.Iterator[int]{}, types.NewInfiniteRange())
+				assert.Equal(t, 5, len(parts))
+				assert.Equal(t, []int{9}, toArray(parts[4]))
+
+				parts = Reduce(partitions, []types.Iterator[int]{}, types.NewFiniteRange(types.WithEnd(10)))
+				assert.Equal(t, 4, len(parts))
+				assert.Equal(t, []int{3, 4, 5}, toArray(parts[1]))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFunctionParallelReduce(t *testing.T) {
+	source := make([]int, 10000)
+	for i := range source {
+		source[i] = i
+	}
+	sum := func(acc any, reduced *Reduced, items ...int) any {
+		if len(items) == 0 || reduced.Stopped() {
+			return acc
+		}
+		return acc.(int) + items[0]
+	}
+	add := func(a, b int) int { return a + b }
+	zero := func() int { return 0 }
+
+	expected := Reduce(Filter(IsEven)(sum), 0, types.NewSliceIterator(source))
+	output, err := ParallelReduce(Filter(IsEven), sum, zero, add, source)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, output)
+	output, err = ParallelReduce(Filter(IsEven), sum, zero, add, source, WithWorkers(3), WithChunkSize(7))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, output)
+	output, err = ParallelReduce(Filter(IsEven), sum, zero, add, []int{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, output)
+
+	concat := func(a, b []int) []int { return append(a, b...) }
+	empty := func() []int { return []int{} }
+	items, err := ParallelReduce(Map(IntIncrementer), Append[int], empty, concat, source, WithOrdered(true), WithChunkSize(100))
+	assert.NoError(t, err)
+	assert.Equal(t, Reduce(Map(IntIncrementer)(Append[int]), []int{}, types.NewSliceIterator(source)), items)
+
+	indexed := pvector.NewVectorFromArray(source)
+	output, err = ParallelReduceIndexed(Filter(IsEven), sum, zero, add, indexed, WithChunkSize(64))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, output)
+}
+
+func TestFunctionParallelReduceEarlyTermination(t *testing.T) {
+	source := make([]int, 1000)
+	for i := range source {
+		source[i] = i
+	}
+	concat := func(a, b []int) []int { return append(a, b...) }
+	empty := func() []int { return []int{} }
+	// chunk holding 250 stops, so chunks after it are left out
+	output, err := ParallelReduce(TakeWhile(LessThan(250)), Append[int], empty, concat, source, WithOrdered(true), WithChunkSize(100), WithWorkers(4))
+	assert.NoError(t, err)
+	expected := make([]int, 250)
+	for i := range expected {
+		expected[i] = i
+	}
+	assert.Equal(t, expected, output)
+}
+
+func TestFunctionParallelReduceErrors(t *testing.T) {
+	source := make([]string, 1000)
+	for i := range source {
+		source[i] = strconv.Itoa(i)
+	}
+	source[420], source[730] = "x", "y"
+	concat := func(a, b []int) []int { return append(a, b...) }
+	empty := func() []int { return []int{} }
+
+	for _, ordered := range []bool{true, false} {
+		_, err := ParallelReduce(MapE(strconv.Atoi), Append[int], empty, concat, source, WithOrdered(ordered), WithChunkSize(100), WithWorkers(4))
+		var reduceErr *ReduceError
+		assert.ErrorAs(t, err, &reduceErr)
+		// first failing chunk in input order is reported, indexing the source
+		assert.Equal(t, 420, reduceErr.Index)
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+	}
+
+	// failure after a chunk stopping early is never reached
+	_, err := ParallelReduce(Combine(TakeWhile(func(s string) bool { return s != "100" }), MapE(strconv.Atoi)), Append[int], empty, concat, source, WithChunkSize(100), WithWorkers(4))
+	assert.NoError(t, err)
+
+	// failing chunk cancels chunks after it which have not started yet
+	started := atomic.Int64{}
+	counting := Map(func(s string) string {
+		started.Add(1)
+		return s
+	})
+	source[0] = "x"
+	_, err = ParallelReduce(Combine(counting, MapE(strconv.Atoi)), Append[int], empty, concat, source, WithChunkSize(10), WithWorkers(1))
+	assert.Error(t, err)
+	assert.Equal(t, int64(1), started.Load())
+}
+
+func TestFunctionParallelReducePanic(t *testing.T) {
+	boom := Map(func(i int) int {
+		if i == 42 {
+			panic("boom")
+		}
+		return i
+	})
+	source := make([]int, 100)
+	source[50] = 42
+	assert.PanicsWithValue(t, "boom", func() {
+		ParallelReduce(boom, Append[int], func() []int { return nil }, func(a, b []int) []int { return append(a, b...) }, source, WithChunkSize(10))
+	})
+}
+
+func feed[T any](items ...T) <-chan T {
+	ch := make(chan T, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+func drain[T any](ch <-chan T) []T {
+	ret := make([]T, 0)
+	for item := range ch {
+		ret = append(ret, item)
+	}
+	return ret
+}
+
+func TestFunctionChan(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out, errs := Chan(ctx, Combine(Filter(IsEven), Map(IntIncrementer)), feed(1, 2, 3, 4, 5, 6))
+	assert.Equal(t, []int{3, 5, 7}, drain(out))
+	assert.NoError(t, <-errs)
+
+	// partial partition is flushed once input is closed
+	parts, errs := Chan(ctx, PartitionAll[int](2), feed(1, 2, 3))
+	sizes := make([]int, 0)
+	for part := range parts {
+		sizes = append(sizes, len(toArray(part)))
+	}
+	assert.Equal(t, []int{2, 1}, sizes)
+	assert.NoError(t, <-errs)
+
+	// input is never closed, so output only ends because Take stops
+	in := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case in <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	out, errs = Chan(ctx, Take[int](3), in)
+	assert.Equal(t, []int{0, 1, 2}, drain(out))
+	assert.NoError(t, <-errs)
+
+	// context done after input is drained is not a cancellation
+	late, cancelLate := context.WithCancel(context.Background())
+	cancelling := func(rf ReducerFunction[int]) ReducerFunction[int] {
+		return func(acc any, reduced *Reduced, items ...int) any {
+			if len(items) == 0 {
+				cancelLate()
+			}
+			return rf(acc, reduced, items...)
+		}
+	}
+	out, errs = Chan(late, cancelling, feed(1, 2))
+	assert.Equal(t, []int{1, 2}, drain(out))
+	assert.NoError(t, <-errs)
+}
+
+func TestFunctionChanErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out, errs := Chan(ctx, Map(IntIncrementer), make(chan int))
+	cancel()
+	assert.Empty(t, drain(out))
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	boom := Map(func(i int) int {
+		panic("boom")
+	})
+	out, errs = Chan(context.Background(), boom, feed(1))
+	assert.Empty(t, drain(out))
+	var panicErr *PanicError
+	assert.True(t, errors.As(<-errs, &panicErr))
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "panic")
+}
+
+func TestFunctionChanReduce(t *testing.T) {
+	ctx := context.Background()
+	output, err := ChanReduce(ctx, Filter(IsEven), Append[int], []int{}, feed(1, 2, 3, 4))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, output)
+
+	output, err = ChanReduce(ctx, Take[int](2), Append[int], []int{}, feed(1, 2, 3, 4))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, output)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = ChanReduce(cancelled, Filter(IsEven), Append[int], []int{}, make(chan int))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	boom := Map(func(i int) int {
+		panic("boom")
+	})
+	_, err = ChanReduce(ctx, boom, Append[int], []int{}, feed(1))
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+}
+
+func TestFunctionErrors(t *testing.T) {
+	parse := MapE(func(s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	output, err := ReduceE(parse(Append[int]), []int{}, types.NewSliceIterator([]string{"1", "2", "3"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, output)
+
+	output, err = ReduceE(parse(Append[int]), []int{}, types.NewSliceIterator([]string{"1", "x", "3"}))
+	var reduceErr *ReduceError
+	assert.ErrorAs(t, err, &reduceErr)
+	assert.Equal(t, 1, reduceErr.Index)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Equal(t, []int{1}, output)
+
+	// Reduce stops silently
+	assert.Equal(t, []int{1}, Reduce(parse(Append[int]), []int{}, types.NewSliceIterator([]string{"1", "x", "3"})))
+
+	// sequences and chained iterators report errors too
+	_, err = ReduceSeqE(parse(Append[int]), []int{}, slices.Values([]string{"1", "2", "x"}))
+	assert.ErrorAs(t, err, &reduceErr)
+	assert.Equal(t, 2, reduceErr.Index)
+	output, err = TransduceE(parse, Append[int], []int{}, types.NewSliceIterator([]string{"1", "2"}), types.NewSliceIterator([]string{"x"}))
+	assert.ErrorAs(t, err, &reduceErr)
+	assert.Equal(t, 2, reduceErr.Index)
+	assert.Equal(t, []int{1, 2}, output)
+	output, err = TransduceE(parse, Append[int], []int{}, types.NewSliceIterator([]string{"1"}), types.NewSliceIterator([]string{"2"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, output)
+
+	positive := FilterE(func(i int) (bool, error) {
+		if i == 0 {
+			return false, errors.New("zero")
+		}
+		return i > 0, nil
+	})
+	ints, err := ReduceE(positive(Append[int]), []int{}, types.NewSliceIterator([]int{1, -2, 3}))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, ints)
+	_, err = ReduceE(positive(Append[int]), []int{}, types.NewSliceIterator([]int{1, -2, 0, 3}))
+	assert.ErrorAs(t, err, &reduceErr)
+	assert.Equal(t, 2, reduceErr.Index)
+	assert.EqualError(t, err, "transducer: item 2: zero")
+
+	// completion still runs, flushing partial partition
+	partitions, err := ReduceE(Combine(parse, PartitionAll[int](2))(Append[types.Iterator[int]]), []types.Iterator[int]{}, types.NewSliceIterator([]string{"1", "2", "3", "x"}))
+	assert.Error(t, err)
+	assert.Len(t, partitions, 2)
+	assert.Equal(t, []int{3}, toArray(partitions[1]))
+
+	// errors raised behind built-in transducers reach ReduceE
+	nonEmpty := Filter(func(s string) bool { return s != "" })
+	output, err = ReduceE(Combine(nonEmpty, parse)(Append[int]), []int{}, types.NewSliceIterator([]string{"1", "", "x", "3"}))
+	assert.ErrorAs(t, err, &reduceErr)
+	assert.Equal(t, 2, reduceErr.Index)
+	assert.Equal(t, []int{1}, output)
+
+	output, err = ReduceE(Combine(Take[string](5), parse)(Append[int]), []int{}, types.NewSliceIterator([]string{"1", "x", "3"}))
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Equal(t, []int{1}, output)
+
+	nested := Combine(Skip[string](1), Combine(Map(strings.TrimSpace), Combine(parse, Map(IntIncrementer))))(Append[int])
+	output, err = ReduceE(nested, []int{}, types.NewSliceIterator([]string{"x", " 1", "2 ", "y", "3"}))
+	assert.ErrorAs(t, err, &reduceErr)
+	assert.Equal(t, 3, reduceErr.Index)
+	assert.Equal(t, []int{2, 3}, output)
+	// failed run leaves nothing behind for the next one
+	output, err = ReduceE(nested, []int{}, types.NewSliceIterator([]string{"x", "1"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, output)
+
+	// failable functions are not called once the process stopped
+	calls := 0
+	counted := MapE(func(i int) (int, error) {
+		calls++
+		return i, nil
+	})
+	output, err = ReduceE(Combine(counted, Take[int](2))(Append[int]), []int{}, types.NewInfiniteRange())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, output)
+	assert.Equal(t, 2, calls)
+	reduced := &Reduced{}
+	reduced.Stop()
+	counted(Append[int])([]int{}, reduced, 5)
+	assert.Equal(t, 2, calls)
+
+	// errors raised while flushing on completion are reported past the end
+	full := MapE(func(part types.Iterator[int]) ([]int, error) {
+		if items := toArray(part); len(items) == 2 {
+			return items, nil
+		}
+		return nil, errors.New("short")
+	})
+	pairs, err := ReduceE(Combine(PartitionAll[int](2), full)(Append[[]int]), [][]int{}, types.NewFiniteRange(types.WithEnd(5)))
+	assert.ErrorAs(t, err, &reduceErr)
+	assert.Equal(t, 5, reduceErr.Index)
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}}, pairs)
+
+	out, errs := Chan(context.Background(), parse, feed("1", "x"))
+	assert.Equal(t, []int{1}, drain(out))
+	assert.ErrorAs(t, <-errs, &reduceErr)
+	_, err = ChanReduce(context.Background(), parse, Append[int], []int{}, feed("x"))
+	assert.ErrorAs(t, err, &reduceErr)
+}
+
+func TestFunctionWindows(t *testing.T) {
+	parts := Reduce(SlidingWindow[int](2, 1)(Append[types.Iterator[int]]), []types.Iterator[int]{}, types.NewSliceIterator([]int{1, 2, 3}))
+	assert.Equal(t, 2, len(parts))
+	assert.Equal(t, []int{2, 3}, toArray(parts[1]))
+
+	ts := func(i int) time.Time {
+		return time.Unix(int64(i), 0)
+	}
+	parts = Reduce(SessionWindow(time.Second, ts)(Append[types.Iterator[int]]), []types.Iterator[int]{}, types.NewSliceIterator([]int{1, 2, 5, 6, 9}))
+	assert.Equal(t, 3, len(parts))
+	assert.Equal(t, []int{5, 6}, toArray(parts[1]))
+
+	parts = Reduce(TumblingWindow(5*time.Second, ts)(Append[types.Iterator[int]]), []types.Iterator[int]{}, types.NewSliceIterator([]int{1, 2, 5, 6, 9}))
+	assert.Equal(t, 2, len(parts))
+	assert.Equal(t, []int{5, 6, 9}, toArray(parts[1]))
+
+	parts = Reduce(TumblingWindowBy(5, IntIncrementer)(Append[types.Iterator[int]]), []types.Iterator[int]{}, types.NewSliceIterator([]int{1, 2, 5, 6, 9}))
+	assert.Equal(t, 3, len(parts))
+	assert.Equal(t, []int{9}, toArray(parts[2]))
+}
+
+func TestFunctionStateful(t *testing.T) {
+	source := func() types.Iterator[int] {
+		return types.NewSliceIterator([]int{1, 1, 2, 3, 3, 1})
+	}
+	assert.Equal(t, []int{1, 2, 3, 1}, Reduce(Dedupe[int]()(Append[int]), []int{}, source()))
+	assert.Equal(t, []int{1, 2, 3}, Reduce(Distinct(func(i int) int { return i })(Append[int]), []int{}, source()))
+	assert.Equal(t, "a,b", Reduce(Interpose(",")(StringAppend("")), "", types.NewSliceIterator([]string{"a", "b"})))
+	assert.Equal(t, []int{1, 2, 4}, Reduce(MapIndexed(func(i, v int) int { return i + v })(Append[int]), []int{}, types.NewSliceIterator([]int{1, 1, 2})))
+	assert.Equal(t, []int{3, 3}, Reduce(Remove(LessThan(3))(Append[int]), []int{}, source()))
+	assert.Equal(t, []int{2, 8}, Reduce(Keep(func(i int) (int, bool) { return i * 2, i != 3 })(Append[int]), []int{}, types.NewSliceIterator([]int{1, 3, 4})))
+	pairs := Mapcat(func(i int) types.Iterator[int] { return types.NewSliceIterator([]int{i, -i}) })
+	assert.Equal(t, []int{1, -1, 2, -2}, Reduce(pairs(Append[int]), []int{}, types.NewSliceIterator([]int{1, 2})))
+	nested := types.NewSliceIterator([]types.Iterator[int]{source(), source()})
+	assert.Equal(t, 12, len(Reduce(Cat[int]()(Append[int]), []int{}, nested)))
+	assert.Equal(t, []int{1, 2, 4}, Reduce(Scan(func(acc, i int) int { return acc + i }, 0)(Append[int]), []int{}, types.NewSliceIterator([]int{1, 1, 2})))
+	assert.Equal(t, []int{9, 9, 2}, Reduce(Combine(Take[int](3), Replace(map[int]int{1: 9}))(Append[int]), []int{}, source()))
+	assert.Equal(t, Reduce(RandomSample[int](0.5, 7)(Append[int]), []int{}, source()), Reduce(RandomSample[int](0.5, 7)(Append[int]), []int{}, source()))
+}
+
+func TestFunctionAggregate(t *testing.T) {
+	source := func() types.Iterator[int] {
+		return types.NewSliceIterator([]int{3, 1, 4, 1, 5})
+	}
+	assert.Equal(t, 14, Reduce(Sum[int](), 0, source()))
+	assert.Equal(t, 60, Reduce(Product[int](), 1, source()))
+	assert.Equal(t, 4, Reduce(Filter(IsOdd)(Count[int]()), 0, source()))
+	assert.Equal(t, 1, Reduce(Min[int](), 0, source()))
+	assert.Equal(t, 5, Reduce(Max[int](), 0, source()))
+	assert.Equal(t, 4, Reduce(MaxBy(func(i int) int { return i % 5 }), 0, source()))
+	assert.Equal(t, 5, Reduce(MinBy(func(i int) int { return i % 5 }), 0, source()))
+	assert.InDelta(t, 2.8, Reduce(Mean[int](), 0.0, source()), 1e-12)
+	assert.InDelta(t, 2.56, Reduce(Variance[int](), 0.0, source()), 1e-12)
+	assert.Equal(t, map[bool][]int{false: {4}, true: {3, 1, 1, 5}}, Reduce(GroupBy(IsOdd), map[bool][]int{}, source()))
+	assert.Equal(t, map[int]int{3: 1, 1: 2, 4: 1, 5: 1}, Reduce(Frequencies[int](), map[int]int{}, source()))
+	assert.Equal(t, 3, Reduce(First[int](), 0, source()))
+	assert.Equal(t, 5, Reduce(Last[int](), 0, source()))
+	assert.Equal(t, []any{14, []int{3, 1, 4, 1, 5}}, Reduce(Juxt(Sum[int](), Append[int]), []any{0, []int{}}, source()))
+
+	// shared reducing functions keep separate state per run
+	mean := Mean[int]()
+	assert.Equal(t, Reduce(mean, 0.0, source()), Reduce(mean, 0.0, source()))
+}
+
+type counterSink struct {
+	total int
+}
+
+func (s *counterSink) Conj(item int) (any, error) {
+	if item < 0 {
+		return s, errors.New("negative")
+	}
+	s.total += item
+	return s, nil
+}
+
+type celsius float64
+
+func TestFunctionAppendSinks(t *testing.T) {
+	source := func() types.Iterator[int] {
+		return types.NewSliceIterator([]int{1, 2, 2})
+	}
+	pairs := Map(func(i int) types.Pair[int, string] { return types.Pair[int, string]{Key: i, Value: IntStringfy(i)} })
+
+	assert.Equal(t, map[int]string{1: "1", 2: "2"}, into(t, map[int]string{}, pairs, source()))
+	assert.Equal(t, map[int]string{1: "1", 2: "2"}, into(t, map[int]string(nil), pairs, source()))
+	assert.Equal(t, map[int]struct{}{0: {}, 1: {}}, into(t, map[int]struct{}{}, Map(IntDecrementer), source()))
+	assert.Equal(t, map[int]bool{2: true, 3: true}, into(t, map[int]bool{}, Map(IntIncrementer), source()))
+
+	var sb strings.Builder
+	into(t, &sb, Map(IntStringfy), source())
+	assert.Equal(t, "122", sb.String())
+	assert.Equal(t, "122", into(t, "", Map(IntIncrementer), types.NewSliceIterator([]int{0, 1, 1})))
+
+	ch := make(chan int, 3)
+	into(t, ch, Map(IntIncrementer), source())
+	close(ch)
+	assert.Equal(t, []int{2, 3, 3}, drain(ch))
+
+	m := into(t, hashmap.New[int, string](), pairs, source())
+	assert.Equal(t, 2, m.Count())
+	set := into(t, sortedmap.NewOrderedSet[int](), Map(IntIncrementer), source())
+	assert.Equal(t, []int{2, 3}, slices.Collect(set.All()))
+	sorted := into(t, sortedmap.NewOrdered[int, string](), pairs, source())
+	assert.Equal(t, 2, sorted.Count())
+	vec := into(t, pvector.NewVector[int](), Map(IntIncrementer), source())
+	assert.Equal(t, []int{2, 3, 3}, slices.Collect(vec.All()))
+
+	sink, err := ReduceE(Append[int], &counterSink{}, source())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, sink.total)
+	_, err = ReduceE(Append[int], &counterSink{}, types.NewSliceIterator([]int{1, -1, 2}))
+	assert.EqualError(t, err, "transducer: item 1: negative")
+
+	// celsius is only registered for float64 accumulators
+	_, err = ReduceE(Append[celsius], 0, types.NewSliceIterator([]celsius{1}))
+	assert.ErrorIs(t, err, ErrNoSink)
+	// unsupported targets fail behind transducers too
+	_, err = ReduceE(Map(func(c celsius) celsius { return c })(Append[celsius]), 0, types.NewSliceIterator([]celsius{1}))
+	assert.ErrorIs(t, err, ErrNoSink)
+	_, err = Into(struct{}{}, Map(IntIncrementer), source())
+	var reduceErr *ReduceError
+	assert.ErrorAs(t, err, &reduceErr)
+	assert.Equal(t, 0, reduceErr.Index)
+	assert.ErrorIs(t, err, ErrNoSink)
+	_, err = Into(map[string]int{}, Map(IntIncrementer), source())
+	assert.ErrorIs(t, err, ErrNoSink)
+	// items looking like pairs are not pairs
+	type record struct {
+		Key   int
+		Value string
+	}
+	_, err = Into(map[int]string{}, Map(func(i int) record { return record{i, IntStringfy(i)} }), source())
+	assert.ErrorIs(t, err, ErrNoSink)
+	// pairs not fitting the map fail instead of panicking
+	_, err = Into(map[string]string{}, pairs, source())
+	assert.ErrorIs(t, err, ErrNoSink)
+	anyPairs := Map(func(i int) types.Pair[any, any] { return types.Pair[any, any]{Key: i} })
+	assert.Equal(t, map[int]*int{1: nil, 2: nil}, into(t, map[int]*int{}, anyPairs, source()))
+	_, err = Into(map[int]int{}, anyPairs, source())
+	assert.ErrorIs(t, err, ErrNoSink)
+
+	type label string
+	assert.Equal(t, label("n:122"), into(t, label("n:"), Map(IntStringfy), source()))
+	RegisterSink(func(acc float64, item celsius) (float64, error) {
+		return max(acc, float64(item)), nil
+	})
+	warmest, err := ReduceE(Append[celsius], 0.0, types.NewSliceIterator([]celsius{21, 25, 19}))
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, warmest)
+}
@@ -45,19 +45,26 @@ var (
 
 // reducing functions
 var (
+	// Reducing function appending each item followed by seperator to the
+	// string accumulator. Items are written to a builder created once per
+	// reducing process, starting with the initial accumulator.
 	StringAppend = func(seperator string) ReducerFunction[string] {
-		builder := strings.Builder{}
-		return func(ret any, reduced *bool, items ...string) any {
-			acc := ""
-			if len(items) > 0 && !*reduced {
+		key := &stateKey{}
+		return func(ret any, reduced *Reduced, items ...string) any {
+			builder, ok := loadState[*strings.Builder](reduced, key)
+			if !ok {
+				builder = &strings.Builder{}
+				acc, _ := ret.(string)
+				builder.WriteString(acc)
+				storeState(reduced, key, builder)
+			}
+			if len(items) == 0 {
+				deleteState(reduced, key)
+			} else if !reduced.Stopped() {
 				builder.WriteString(items[0])
 				builder.WriteString(seperator)
-				acc = builder.String()
-			} else {
-				acc = builder.String()
-				builder.Reset()
 			}
-			return acc
+			return builder.String()
 		}
 	}
 )
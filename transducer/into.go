@@ -0,0 +1,14 @@
+package transducer
+
+import (
+	"github.com/igumus/gdsa/types"
+)
+
+// Reduces items of given iterators into target using Append, so target may
+// be any accumulator Append knows, e.g. a slice, list, map, set, string or
+// io.Writer. Returns error stopping the reducing process as *ReduceError,
+// wrapping ErrNoSink when target is not supported.
+func Into[R, A, B any](target R, xf Transducer[A, B], its ...types.Iterator[B]) (R, error) {
+	acc, _, err := reduce(xf(Append[A]), target, chain(its))
+	return acc, err
+}
@@ -0,0 +1,228 @@
+package transducer
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/igumus/gdsa/types"
+)
+
+type ParallelOption func(*parallelOptions)
+
+type parallelOptions struct {
+	workers   int
+	chunkSize int
+	ordered   bool
+}
+
+func applyParallelOptions(size int, opts ...ParallelOption) *parallelOptions {
+	ret := &parallelOptions{
+		workers:   runtime.GOMAXPROCS(0),
+		chunkSize: 0,
+		ordered:   false,
+	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	if ret.workers < 1 {
+		ret.workers = 1
+	}
+	if ret.chunkSize < 1 {
+		// a few chunks per worker keeps workers busy when chunks are uneven
+		ret.chunkSize = max(1, (size+ret.workers*4-1)/(ret.workers*4))
+	}
+	return ret
+}
+
+// Sets maximum number of chunks reduced at the same time. Defaults to
+// GOMAXPROCS.
+func WithWorkers(n int) ParallelOption {
+	return func(po *parallelOptions) {
+		po.workers = n
+	}
+}
+
+// Sets number of items in each chunk. Defaults to splitting source into
+// four chunks per worker.
+func WithChunkSize(n int) ParallelOption {
+	return func(po *parallelOptions) {
+		po.chunkSize = n
+	}
+}
+
+// Sets whether chunk results are combined in input order. Otherwise they
+// are combined in completion order, so combine function must be
+// commutative to get deterministic results.
+func WithOrdered(ordered bool) ParallelOption {
+	return func(po *parallelOptions) {
+		po.ordered = ordered
+	}
+}
+
+// Reduces items of given slice in parallel. Source is split into chunks,
+// each chunk is transduced into an accumulator created by init using a
+// bounded pool of workers, and chunk accumulators are merged by combine.
+//
+// Transducer runs independently per chunk, so stateful transducers such as
+// Take apply to each chunk rather than to the whole source. When a chunk
+// stops early, chunks after it are abandoned and left out of the result.
+// When a chunk fails, chunks after it are cancelled and the error is
+// returned as *ReduceError indexing the source, so the error reported is
+// the first one in input order, as for a sequential reduction.
+func ParallelReduce[R, A, B any](xf Transducer[A, B], rf ReducerFunction[A], init func() R, combine func(R, R) R, source []B, opts ...ParallelOption) (R, error) {
+	get := func(i int) B {
+		return source[i]
+	}
+	return parallelReduce(xf, rf, init, combine, len(source), get, opts...)
+}
+
+// Reduces items of given indexed collection in parallel, as ParallelReduce
+// does for slices.
+func ParallelReduceIndexed[R, A, B any](xf Transducer[A, B], rf ReducerFunction[A], init func() R, combine func(R, R) R, source types.Indexed[B], opts ...ParallelOption) (R, error) {
+	get := func(i int) B {
+		item, _ := source.Get(i)
+		return item
+	}
+	return parallelReduce(xf, rf, init, combine, source.Count(), get, opts...)
+}
+
+type chunkResult[R any] struct {
+	index int
+	acc   R
+}
+
+func parallelReduce[R, A, B any](xf Transducer[A, B], rf ReducerFunction[A], init func() R, combine func(R, R) R, size int, get func(int) B, opts ...ParallelOption) (R, error) {
+	cfg := applyParallelOptions(size, opts...)
+	chunks := (size + cfg.chunkSize - 1) / cfg.chunkSize
+
+	// index of the first chunk which stopped early or failed; chunks after
+	// it are abandoned
+	var stopAt atomic.Int64
+	stopAt.Store(int64(chunks))
+	abandoned := func(index int) bool {
+		return int64(index) > stopAt.Load()
+	}
+	stop := func(index int) {
+		for current := stopAt.Load(); int64(index) < current; current = stopAt.Load() {
+			if stopAt.CompareAndSwap(current, int64(index)) {
+				return
+			}
+		}
+	}
+
+	// items of a chunk, ending early once the chunk is abandoned
+	items := func(index int) iter.Seq[B] {
+		return func(yield func(B) bool) {
+			end := min((index+1)*cfg.chunkSize, size)
+			for i := index * cfg.chunkSize; i < end && !abandoned(index); i++ {
+				if !yield(get(i)) {
+					return
+				}
+			}
+		}
+	}
+
+	var (
+		wg        sync.WaitGroup
+		panicOnce sync.Once
+		panicked  any
+		failMu    sync.Mutex
+		failed    *ReduceError
+		failedAt  int
+	)
+	// records error of a chunk, keeping the first one in input order, and
+	// cancels chunks after it; chunks before it keep running, as they may
+	// stop or fail earlier
+	fail := func(index int, err *ReduceError) {
+		failMu.Lock()
+		defer failMu.Unlock()
+		if failed == nil || index < failedAt {
+			failed = &ReduceError{Index: index*cfg.chunkSize + err.Index, Err: err.Err}
+			failedAt = index
+		}
+		stop(index)
+	}
+	// reduces a chunk, recording panic of user functions to be raised by
+	// the caller once workers are done
+	run := func(index int) (ret chunkResult[R], ok bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicOnce.Do(func() { panicked = r })
+				stopAt.Store(-1)
+				ok = false
+			}
+		}()
+		acc, stopped, err := reduce(xf(rf), init(), items(index))
+		if reduceErr, isReduceErr := err.(*ReduceError); isReduceErr {
+			fail(index, reduceErr)
+			return ret, false
+		}
+		if stopped {
+			stop(index)
+		}
+		return chunkResult[R]{index: index, acc: acc}, true
+	}
+
+	indexes := make(chan int)
+	results := make(chan chunkResult[R], chunks)
+	for w := 0; w < min(cfg.workers, chunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				if abandoned(index) {
+					continue
+				}
+				if result, ok := run(index); ok {
+					results <- result
+				}
+			}
+		}()
+	}
+	go func() {
+		for index := 0; index < chunks; index++ {
+			indexes <- index
+		}
+		close(indexes)
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	ret := init()
+	if cfg.ordered {
+		ordered := make([]*R, chunks)
+		for result := range results {
+			ordered[result.index] = &result.acc
+		}
+		for index, acc := range ordered {
+			if acc != nil && !abandoned(index) {
+				ret = combine(ret, *acc)
+			}
+		}
+	} else {
+		// chunk may be abandoned after it completed, so results are combined
+		// only once every worker is done
+		completed := make([]chunkResult[R], 0, chunks)
+		for result := range results {
+			completed = append(completed, result)
+		}
+		for _, result := range completed {
+			if !abandoned(result.index) {
+				ret = combine(ret, result.acc)
+			}
+		}
+	}
+
+	if panicked != nil {
+		panic(panicked)
+	}
+	// error of a chunk after one which stopped early is never reached
+	if failed != nil && !abandoned(failedAt) {
+		return ret, failed
+	}
+	return ret, nil
+}
@@ -0,0 +1,104 @@
+package transducer
+
+// Reduced holds the state of a single reducing process. Reduce creates one
+// for every run and passes it to each reducing function of the stack, which
+// forwards it unchanged to the reducing function it wraps.
+//
+// Stop ends the reducing process once the current input is processed, and
+// Fail ends it with an error, which ReduceE reports. Stateful reducing
+// functions keep their per-run state in Reduced, so a composed reducing
+// function carries no state of its own and can be shared by sequential and
+// concurrent runs. The zero value is ready to use; when driving a reducing
+// function by hand, pass the same Reduced to every call of a run, including
+// completion.
+type Reduced struct {
+	stopped bool
+	run     *run
+}
+
+// run is the state shared by every layer of a reducing process.
+type run struct {
+	states map[*stateKey]any
+	err    error
+	// sink resolved by Append
+	sink any
+}
+
+// Stops the reducing process.
+func (r *Reduced) Stop() {
+	r.stopped = true
+}
+
+// Reports whether the reducing process is stopped.
+func (r *Reduced) Stopped() bool {
+	return r.stopped
+}
+
+// Stops the reducing process with given error. Reducing functions call Fail
+// instead of Stop when they cannot process an input. Only the first error
+// of a run is kept.
+func (r *Reduced) Fail(err error) {
+	if run := r.shared(); run.err == nil {
+		run.err = err
+	}
+	r.Stop()
+}
+
+// Returns the error which stopped the reducing process, raised by any
+// reducing function of the stack.
+func (r *Reduced) Err() error {
+	if r.run == nil {
+		return nil
+	}
+	return r.run.err
+}
+
+// Reports whether the reducing process must not be given more inputs.
+func (r *Reduced) done() bool {
+	return r.stopped || r.Err() != nil
+}
+
+func (r *Reduced) shared() *run {
+	if r.run == nil {
+		r.run = &run{}
+	}
+	return r.run
+}
+
+// Returns Reduced for reducing functions wrapped by a typed transducer. It
+// shares the run with r but has its own stop flag, so typed steps decide
+// how a stop below them propagates, and their completion can still flush
+// buffered items downstream after the process stopped.
+func (r *Reduced) nested() *Reduced {
+	return &Reduced{run: r.shared()}
+}
+
+// stateKey identifies per-run state of a reducing function. It is not zero
+// sized, so every key is a distinct pointer.
+type stateKey struct {
+	_ byte
+}
+
+// Returns per-run state stored under given key.
+func loadState[S any](r *Reduced, key *stateKey) (S, bool) {
+	s, ok := r.shared().states[key]
+	if !ok {
+		var ret S
+		return ret, false
+	}
+	return s.(S), true
+}
+
+// Stores per-run state under given key.
+func storeState(r *Reduced, key *stateKey, s any) {
+	run := r.shared()
+	if run.states == nil {
+		run.states = make(map[*stateKey]any)
+	}
+	run.states[key] = s
+}
+
+// Releases per-run state stored under given key, once completion ran.
+func deleteState(r *Reduced, key *stateKey) {
+	delete(r.shared().states, key)
+}
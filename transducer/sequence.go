@@ -0,0 +1,90 @@
+package transducer
+
+import (
+	"github.com/igumus/gdsa/types"
+)
+
+// SequenceIterator is a lazy iterator over items produced by a transducer.
+// Err returns the error which stopped the reducing process, if any. Close
+// runs completion of a sequence abandoned before it is exhausted, so the
+// reducing functions release their per-run state; items flushed by
+// completion are dropped. Closing an exhausted sequence does nothing.
+type SequenceIterator[A any] interface {
+	types.Iterator[A]
+	Err() error
+	Close() error
+}
+
+// sequence pulls items from source through the reducing function only when
+// the consumer asks for more. Items emitted by the transducer stack are
+// buffered, as a single input may produce zero or many outputs.
+type sequence[A, B any] struct {
+	source    types.Iterator[B]
+	rf        ReducerFunction[B]
+	buffer    []A
+	reduced   Reduced
+	completed bool
+}
+
+// Creates lazy iterator applying given transducer to the items of given
+// iterator. Source is consumed on demand, so infinite iterators are
+// supported as long as the consumer stops pulling; consumers abandoning the
+// sequence should Close it.
+func Sequence[A, B any](xf Transducer[A, B], it types.Iterator[B]) SequenceIterator[A] {
+	s := &sequence[A, B]{
+		source: it,
+		buffer: make([]A, 0),
+	}
+	s.rf = xf(s.collect)
+	return s
+}
+
+// Bottom reducing function of the stack, buffering emitted items.
+func (s *sequence[A, B]) collect(acc any, reduced *Reduced, items ...A) any {
+	if len(items) > 0 && !reduced.Stopped() {
+		s.buffer = append(s.buffer, items[0])
+	}
+	return acc
+}
+
+// Steps the reducing function until an item is buffered or the source is
+// exhausted, running completion once at the end.
+func (s *sequence[A, B]) fill() {
+	for len(s.buffer) == 0 && !s.completed {
+		if !s.reduced.done() && s.source.HasNext() {
+			s.rf(nil, &s.reduced, s.source.Next())
+		} else {
+			s.rf(nil, &s.reduced)
+			s.completed = true
+		}
+	}
+}
+
+func (s *sequence[A, B]) HasNext() bool {
+	s.fill()
+	return len(s.buffer) > 0
+}
+
+func (s *sequence[A, B]) Next() A {
+	var ret A
+	s.fill()
+	if len(s.buffer) > 0 {
+		ret = s.buffer[0]
+		s.buffer = s.buffer[1:]
+	}
+	return ret
+}
+
+func (s *sequence[A, B]) Err() error {
+	return s.reduced.Err()
+}
+
+func (s *sequence[A, B]) Close() error {
+	if !s.completed {
+		s.reduced.Stop()
+		s.rf(nil, &s.reduced)
+		s.completed = true
+	}
+	s.buffer = s.buffer[:0]
+	return s.Err()
+}
@@ -0,0 +1,288 @@
+package transducer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/igumus/gdsa/types"
+)
+
+// ErrNoSink is reported by Append when it does not know how to add items to
+// its accumulator.
+var ErrNoSink = errors.New("transducer: no sink for accumulator")
+
+// Sink is implemented by accumulators which know how to add items to
+// themselves. Conj returns the accumulator holding the item, which is
+// either the receiver itself or, for persistent collections, a new version.
+type Sink[A any] interface {
+	Conj(A) (any, error)
+}
+
+type registeredSink struct {
+	target reflect.Type
+	item   reflect.Type
+	conj   func(target any, item any) (any, error)
+}
+
+var sinks struct {
+	sync.RWMutex
+	entries []registeredSink
+}
+
+// Registers function adding items of type A to accumulators of type T, so
+// Append can reduce into types which do not implement Sink. When T is an
+// interface, the function is used for every accumulator implementing it.
+// Functions registered later take precedence.
+func RegisterSink[T, A any](conj func(T, A) (T, error)) {
+	sinks.Lock()
+	defer sinks.Unlock()
+	sinks.entries = append(sinks.entries, registeredSink{
+		target: reflect.TypeFor[T](),
+		item:   reflect.TypeFor[A](),
+		conj: func(target any, item any) (any, error) {
+			return conj(target.(T), item.(A))
+		},
+	})
+}
+
+// Returns registered function adding items of type A to given accumulator.
+func registered[A any](target any) (func(any, any) (any, error), bool) {
+	sinks.RLock()
+	defer sinks.RUnlock()
+	targetType, itemType := reflect.TypeOf(target), reflect.TypeFor[A]()
+	if targetType == nil {
+		return nil, false
+	}
+	for i := len(sinks.entries) - 1; i >= 0; i-- {
+		entry := sinks.entries[i]
+		if entry.item != itemType {
+			continue
+		}
+		if entry.target == targetType || (entry.target.Kind() == reflect.Interface && targetType.Implements(entry.target)) {
+			return entry.conj, true
+		}
+	}
+	return nil, false
+}
+
+// Reducing function adding each item to the accumulator. Slices of A are
+// appended to directly; for other accumulators the way to add items is
+// resolved once per reducing process, in the following order:
+//   - accumulators implementing Sink,
+//   - accumulators of types registered with RegisterSink,
+//   - types.List and types.Indexed collect items,
+//   - strings, including named string types, concatenate items; runes are
+//     written as characters and any other item is formatted using
+//     fmt.Sprint,
+//   - channels receive items,
+//   - io.Writer receives items formatted as for strings,
+//   - maps associate types.Pair items, or use items as keys when value type
+//     is struct{} or bool, so the map acts as a set; nil map is replaced
+//     with a new map,
+//   - any other accumulator with Assoc(K, V) method associates types.Pair
+//     items, and with Add(A) method adds items, as library maps and sets do.
+//
+// For any other accumulator the reducing process fails with ErrNoSink,
+// which ReduceE reports.
+func Append[A any](output any, reduced *Reduced, items ...A) any {
+	if len(items) == 0 || reduced.Stopped() {
+		return output
+	}
+	if acc, ok := output.([]A); ok {
+		return append(acc, items[0])
+	}
+
+	run := reduced.shared()
+	sink, ok := run.sink.(*resolvedSink[A])
+	if !ok || sink.target != reflect.TypeOf(output) {
+		sink = &resolvedSink[A]{target: reflect.TypeOf(output), conj: resolve[A](output)}
+		run.sink = sink
+	}
+	acc, err := sink.conj(output, items[0])
+	if err != nil {
+		reduced.Fail(err)
+		return output
+	}
+	return acc
+}
+
+// resolvedSink caches the function adding items to accumulators of target
+// type for a reducing process.
+type resolvedSink[A any] struct {
+	target reflect.Type
+	conj   func(any, A) (any, error)
+}
+
+// Returns function adding items to accumulators of the type of given
+// accumulator, as described by Append.
+func resolve[A any](output any) func(any, A) (any, error) {
+	if _, ok := output.(Sink[A]); ok {
+		return func(acc any, item A) (any, error) {
+			return acc.(Sink[A]).Conj(item)
+		}
+	}
+	if f, ok := registered[A](output); ok {
+		return func(acc any, item A) (any, error) {
+			return f(acc, item)
+		}
+	}
+
+	switch output.(type) {
+	case types.List[A]:
+		return func(acc any, item A) (any, error) {
+			return acc.(types.List[A]).Add(item), nil
+		}
+	case types.Indexed[A]:
+		return func(acc any, item A) (any, error) {
+			return acc.(types.Indexed[A]).Append(item), nil
+		}
+	case string:
+		return func(acc any, item A) (any, error) {
+			return acc.(string) + format(item), nil
+		}
+	case chan A:
+		return func(acc any, item A) (any, error) {
+			acc.(chan A) <- item
+			return acc, nil
+		}
+	case chan<- A:
+		return func(acc any, item A) (any, error) {
+			acc.(chan<- A) <- item
+			return acc, nil
+		}
+	case io.Writer:
+		return func(acc any, item A) (any, error) {
+			_, err := io.WriteString(acc.(io.Writer), format(item))
+			return acc, err
+		}
+	}
+	return resolveReflect[A](output)
+}
+
+// Formats item for string accumulators.
+func format(item any) string {
+	switch item := item.(type) {
+	case string:
+		return item
+	case rune:
+		return string(item)
+	case []byte:
+		return string(item)
+	default:
+		return fmt.Sprint(item)
+	}
+}
+
+// entry is implemented by types.Pair, giving key and value of pairs whose
+// type parameters are only known at run time.
+type entry interface {
+	Entry() (any, any)
+}
+
+// Returns function adding items to maps, named string types and to
+// collections with Assoc or Add methods, whose key and item types are only
+// known at run time. Pair items whose key or value does not fit the
+// accumulator fail with ErrNoSink.
+func resolveReflect[A any](output any) func(any, A) (any, error) {
+	t, itemType := reflect.TypeOf(output), reflect.TypeFor[A]()
+	unsupported := func(acc any, item A) (any, error) {
+		return acc, fmt.Errorf("%w: %T with item %T", ErrNoSink, acc, item)
+	}
+	if t == nil {
+		return unsupported
+	}
+	isPair := itemType.Implements(reflect.TypeFor[entry]())
+
+	switch t.Kind() {
+	case reflect.String:
+		return func(acc any, item A) (any, error) {
+			s := reflect.ValueOf(acc).String() + format(item)
+			return reflect.ValueOf(s).Convert(t).Interface(), nil
+		}
+	case reflect.Map:
+		var set reflect.Value
+		switch {
+		case isPair:
+		case itemType.AssignableTo(t.Key()) && t.Elem() == reflect.TypeFor[struct{}]():
+			set = reflect.ValueOf(struct{}{})
+		case itemType.AssignableTo(t.Key()) && t.Elem().Kind() == reflect.Bool:
+			set = reflect.ValueOf(true).Convert(t.Elem())
+		default:
+			return unsupported
+		}
+		return func(output any, item A) (any, error) {
+			acc := reflect.ValueOf(output)
+			if acc.IsNil() {
+				acc = reflect.MakeMap(t)
+			}
+			if set.IsValid() {
+				acc.SetMapIndex(reflect.ValueOf(item), set)
+				return acc.Interface(), nil
+			}
+			key, value, ok := arguments(item, t.Key(), t.Elem())
+			if !ok {
+				return unsupported(output, item)
+			}
+			acc.SetMapIndex(key, value)
+			return acc.Interface(), nil
+		}
+	}
+
+	if m, ok := t.MethodByName("Assoc"); isPair && ok && m.Type.NumIn() == 3 && m.Type.NumOut() == 1 && !m.Type.IsVariadic() {
+		return func(acc any, item A) (any, error) {
+			key, value, ok := arguments(item, m.Type.In(1), m.Type.In(2))
+			if !ok {
+				return unsupported(acc, item)
+			}
+			args := []reflect.Value{key, value}
+			return reflect.ValueOf(acc).Method(m.Index).Call(args)[0].Interface(), nil
+		}
+	}
+	if m, ok := t.MethodByName("Add"); ok && accepts(m.Type, t, itemType) {
+		return func(acc any, item A) (any, error) {
+			args := []reflect.Value{reflect.ValueOf(item)}
+			return reflect.ValueOf(acc).Method(m.Index).Call(args)[0].Interface(), nil
+		}
+	}
+	return unsupported
+}
+
+// Returns key and value of pair item as arguments of given types, reporting
+// whether they are assignable to them.
+func arguments(item any, keyType, valueType reflect.Type) (reflect.Value, reflect.Value, bool) {
+	key, value := item.(entry).Entry()
+	k, keyOk := argument(key, keyType)
+	v, valueOk := argument(value, valueType)
+	return k, v, keyOk && valueOk
+}
+
+// Returns given value as an argument of given type, reporting whether it is
+// assignable to it. Nil stands for the zero value of t.
+func argument(x any, t reflect.Type) (reflect.Value, bool) {
+	if x == nil {
+		switch t.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+			return reflect.Zero(t), true
+		}
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(x)
+	return v, v.Type().AssignableTo(t)
+}
+
+// Reports whether method of given type, with receiver as its first input,
+// takes given arguments and returns a single result.
+func accepts(method reflect.Type, args ...reflect.Type) bool {
+	if method.NumIn() != len(args) || method.NumOut() != 1 || method.IsVariadic() {
+		return false
+	}
+	for i, arg := range args {
+		if !arg.AssignableTo(method.In(i)) {
+			return false
+		}
+	}
+	return true
+}
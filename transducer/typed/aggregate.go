@@ -0,0 +1,280 @@
+package typed
+
+import (
+	"cmp"
+	"math"
+
+	"github.com/igumus/gdsa/types"
+)
+
+// Aggregating reducers below fold items into a single result. Reducers
+// which need more state than their accumulator keep it per run, so they
+// follow the same Fresh contract as transducer steps.
+
+// Reducer adding items together.
+func Sum[T types.Number]() Reducer[T, T] {
+	return NewReducer(nil, func(acc, item T) (T, bool) { return acc + item, false }, nil)
+}
+
+// Reducer multiplying items together. Init returns one.
+func Product[T types.Number]() Reducer[T, T] {
+	return NewReducer(
+		func() T { return 1 },
+		func(acc, item T) (T, bool) { return acc * item, false },
+		nil,
+	)
+}
+
+// Reducer counting items.
+func Count[T any]() Reducer[int, T] {
+	return NewReducer(nil, func(acc int, _ T) (int, bool) { return acc + 1, false }, nil)
+}
+
+type selectStep[T any] struct {
+	better func(item, current T) bool
+	seen   bool
+}
+
+func (r *selectStep[T]) Init() T {
+	var ret T
+	return ret
+}
+
+func (r *selectStep[T]) Step(acc T, item T) (T, bool) {
+	if !r.seen || r.better(item, acc) {
+		r.seen = true
+		return item, false
+	}
+	return acc, false
+}
+
+func (r *selectStep[T]) Complete(acc T) T {
+	return acc
+}
+
+func (r *selectStep[T]) Fresh() Reducer[T, T] {
+	return &selectStep[T]{better: r.better}
+}
+
+// Reducer selecting the least item. Initial accumulator is replaced by the
+// first item, so reducing no items returns the initial accumulator.
+func Min[T cmp.Ordered]() Reducer[T, T] {
+	return MinBy(func(item T) T { return item })
+}
+
+// Reducer selecting the greatest item. Initial accumulator is replaced by
+// the first item, so reducing no items returns the initial accumulator.
+func Max[T cmp.Ordered]() Reducer[T, T] {
+	return MaxBy(func(item T) T { return item })
+}
+
+// Reducer selecting the first item with the least key given function
+// returns.
+func MinBy[T any, K cmp.Ordered](f func(T) K) Reducer[T, T] {
+	return &selectStep[T]{better: func(item, current T) bool { return cmp.Less(f(item), f(current)) }}
+}
+
+// Reducer selecting the first item with the greatest key given function
+// returns.
+func MaxBy[T any, K cmp.Ordered](f func(T) K) Reducer[T, T] {
+	return &selectStep[T]{better: func(item, current T) bool { return cmp.Less(f(current), f(item)) }}
+}
+
+// welfordStep tracks running mean and sum of squared differences from the
+// mean using Welford's online algorithm, which stays accurate when values
+// are large compared to their spread.
+type welfordStep[T types.Number] struct {
+	result func(count int, mean, m2 float64) float64
+	count  int
+	mean   float64
+	m2     float64
+}
+
+func (r *welfordStep[T]) Init() float64 {
+	return 0
+}
+
+func (r *welfordStep[T]) Step(_ float64, item T) (float64, bool) {
+	r.count++
+	value := float64(item)
+	delta := value - r.mean
+	r.mean += delta / float64(r.count)
+	r.m2 += delta * (value - r.mean)
+	return r.result(r.count, r.mean, r.m2), false
+}
+
+func (r *welfordStep[T]) Complete(acc float64) float64 {
+	return acc
+}
+
+func (r *welfordStep[T]) Fresh() Reducer[float64, T] {
+	return &welfordStep[T]{result: r.result}
+}
+
+// Reducer calculating arithmetic mean of items. Reducing no items returns
+// the initial accumulator.
+func Mean[T types.Number]() Reducer[float64, T] {
+	return &welfordStep[T]{result: func(_ int, mean, _ float64) float64 { return mean }}
+}
+
+// Reducer calculating population variance of items. Reducing no items
+// returns the initial accumulator.
+func Variance[T types.Number]() Reducer[float64, T] {
+	return &welfordStep[T]{result: func(count int, _, m2 float64) float64 { return m2 / float64(count) }}
+}
+
+// Reducer calculating sample variance of items, which is NaN for a single
+// item.
+func SampleVariance[T types.Number]() Reducer[float64, T] {
+	return &welfordStep[T]{result: func(count int, _, m2 float64) float64 {
+		if count < 2 {
+			return math.NaN()
+		}
+		return m2 / float64(count-1)
+	}}
+}
+
+// Reducer grouping items by the key given function returns, keeping items
+// of a group in input order. Nil accumulator is replaced with a new map.
+func GroupBy[K comparable, V any](f func(V) K) Reducer[map[K][]V, V] {
+	return NewReducer(
+		func() map[K][]V { return make(map[K][]V) },
+		func(acc map[K][]V, item V) (map[K][]V, bool) {
+			if acc == nil {
+				acc = make(map[K][]V)
+			}
+			key := f(item)
+			acc[key] = append(acc[key], item)
+			return acc, false
+		},
+		nil,
+	)
+}
+
+// Reducer counting occurrences of each item. Nil accumulator is replaced
+// with a new map.
+func Frequencies[T comparable]() Reducer[map[T]int, T] {
+	return NewReducer(
+		func() map[T]int { return make(map[T]int) },
+		func(acc map[T]int, item T) (map[T]int, bool) {
+			if acc == nil {
+				acc = make(map[T]int)
+			}
+			acc[item]++
+			return acc, false
+		},
+		nil,
+	)
+}
+
+// Reducer returning the first item, stopping the reducing process right
+// after it.
+func First[T any]() Reducer[T, T] {
+	return NewReducer(nil, func(_ T, item T) (T, bool) { return item, true }, nil)
+}
+
+// Reducer returning the last item.
+func Last[T any]() Reducer[T, T] {
+	return NewReducer(nil, func(_ T, item T) (T, bool) { return item, false }, nil)
+}
+
+type juxtStep[Acc, T any] struct {
+	reducers []Reducer[Acc, T]
+	stopped  []bool
+	// whether the accumulator is a copy owned by this run
+	owned bool
+}
+
+func (r *juxtStep[Acc, T]) Init() []Acc {
+	ret := make([]Acc, len(r.reducers))
+	for i, rf := range r.reducers {
+		ret[i] = rf.Init()
+	}
+	return ret
+}
+
+// Returns accumulator of this run, copying the one given by the caller once,
+// so an initial slice is never written to and can be reused.
+func (r *juxtStep[Acc, T]) own(acc []Acc) []Acc {
+	if r.owned {
+		return acc
+	}
+	r.owned = true
+	ret := r.Init()
+	copy(ret, acc)
+	return ret
+}
+
+func (r *juxtStep[Acc, T]) Step(acc []Acc, item T) ([]Acc, bool) {
+	acc = r.own(acc)
+	running := false
+	for i, rf := range r.reducers {
+		if r.stopped[i] {
+			continue
+		}
+		acc[i], r.stopped[i] = rf.Step(acc[i], item)
+		running = running || !r.stopped[i]
+	}
+	return acc, !running
+}
+
+func (r *juxtStep[Acc, T]) Complete(acc []Acc) []Acc {
+	acc = r.own(acc)
+	for i, rf := range r.reducers {
+		acc[i] = rf.Complete(acc[i])
+	}
+	return acc
+}
+
+func (r *juxtStep[Acc, T]) Fresh() Reducer[[]Acc, T] {
+	reducers := make([]Reducer[Acc, T], len(r.reducers))
+	for i, rf := range r.reducers {
+		reducers[i] = Fresh(rf)
+	}
+	return &juxtStep[Acc, T]{reducers: reducers, stopped: make([]bool, len(reducers))}
+}
+
+// Reducer running given reducers over the same items in a single pass,
+// accumulating their results at the same positions. A reducer which stops
+// is no longer stepped; the reducing process stops once every reducer has
+// stopped.
+func Juxt[Acc, T any](reducers ...Reducer[Acc, T]) Reducer[[]Acc, T] {
+	return &juxtStep[Acc, T]{reducers: reducers, stopped: make([]bool, len(reducers))}
+}
+
+type teeStep[A, B, T any] struct {
+	left      Reducer[A, T]
+	right     Reducer[B, T]
+	leftDone  bool
+	rightDone bool
+}
+
+func (r *teeStep[A, B, T]) Init() types.Pair[A, B] {
+	return types.Pair[A, B]{Key: r.left.Init(), Value: r.right.Init()}
+}
+
+func (r *teeStep[A, B, T]) Step(acc types.Pair[A, B], item T) (types.Pair[A, B], bool) {
+	if !r.leftDone {
+		acc.Key, r.leftDone = r.left.Step(acc.Key, item)
+	}
+	if !r.rightDone {
+		acc.Value, r.rightDone = r.right.Step(acc.Value, item)
+	}
+	return acc, r.leftDone && r.rightDone
+}
+
+func (r *teeStep[A, B, T]) Complete(acc types.Pair[A, B]) types.Pair[A, B] {
+	acc.Key = r.left.Complete(acc.Key)
+	acc.Value = r.right.Complete(acc.Value)
+	return acc
+}
+
+func (r *teeStep[A, B, T]) Fresh() Reducer[types.Pair[A, B], T] {
+	return &teeStep[A, B, T]{left: Fresh(r.left), right: Fresh(r.right)}
+}
+
+// Reducer running two reducers with different accumulator types over the
+// same items in a single pass, as Juxt does for reducers of the same type.
+func Tee[A, B, T any](left Reducer[A, T], right Reducer[B, T]) Reducer[types.Pair[A, B], T] {
+	return &teeStep[A, B, T]{left: left, right: right}
+}
@@ -0,0 +1,153 @@
+// Package typed provides a type-safe transducer protocol. Reducers expose
+// explicit init, step and completion phases, so using a reducer with an
+// accumulator or item type it was not built for is a compile error.
+package typed
+
+import (
+	"iter"
+
+	"github.com/igumus/gdsa/types"
+)
+
+// Reducer folds items of type T into an accumulator of type Acc.
+//
+// Init returns the initial accumulator, Step folds an item returning true
+// when the reducing process must stop, and Complete is called exactly once
+// at the end of the process to flush any buffered state. Reducers keeping
+// state between calls also implement Stateful.
+type Reducer[Acc, T any] interface {
+	Init() Acc
+	Step(Acc, T) (Acc, bool)
+	Complete(Acc) Acc
+}
+
+// Stateful is implemented by reducers keeping per-run state, and by reducers
+// wrapping other reducers which may do so. Fresh returns a reducer with the
+// same configuration and its own per-run state, including fresh instances of
+// wrapped reducers. Reduce calls it at the start of every run, so a composed
+// reducer is never mutated and can be shared by sequential and concurrent
+// reductions.
+type Stateful[Acc, T any] interface {
+	Reducer[Acc, T]
+	Fresh() Reducer[Acc, T]
+}
+
+// Returns fresh instance of given reducer when it is Stateful, or the
+// reducer itself otherwise.
+func Fresh[Acc, T any](r Reducer[Acc, T]) Reducer[Acc, T] {
+	if s, ok := r.(Stateful[Acc, T]); ok {
+		return s.Fresh()
+	}
+	return r
+}
+
+// Transducer transforms a reducer of A items into a reducer of B items
+// sharing the same accumulator type.
+type Transducer[Acc, A, B any] func(Reducer[Acc, A]) Reducer[Acc, B]
+
+type funcReducer[Acc, T any] struct {
+	init     func() Acc
+	step     func(Acc, T) (Acc, bool)
+	complete func(Acc) Acc
+}
+
+// Creates reducer from given functions. Nil init returns zero accumulator
+// and nil complete returns accumulator as is. Functions must not keep state
+// between calls, as the reducer is shared by every run.
+func NewReducer[Acc, T any](init func() Acc, step func(Acc, T) (Acc, bool), complete func(Acc) Acc) Reducer[Acc, T] {
+	return &funcReducer[Acc, T]{
+		init:     init,
+		step:     step,
+		complete: complete,
+	}
+}
+
+func (r *funcReducer[Acc, T]) Init() Acc {
+	var ret Acc
+	if r.init != nil {
+		ret = r.init()
+	}
+	return ret
+}
+
+func (r *funcReducer[Acc, T]) Step(acc Acc, item T) (Acc, bool) {
+	return r.step(acc, item)
+}
+
+func (r *funcReducer[Acc, T]) Complete(acc Acc) Acc {
+	if r.complete != nil {
+		return r.complete(acc)
+	}
+	return acc
+}
+
+// forward delegates init and completion phases to the next reducer. It is
+// embedded by transducer steps which only customise the step phase.
+type forward[Acc, A any] struct {
+	next Reducer[Acc, A]
+}
+
+func (f forward[Acc, A]) Init() Acc {
+	return f.next.Init()
+}
+
+func (f forward[Acc, A]) Complete(acc Acc) Acc {
+	return f.next.Complete(acc)
+}
+
+// Returns forward holding a fresh instance of the next reducer.
+func (f forward[Acc, A]) fresh() forward[Acc, A] {
+	return forward[Acc, A]{next: Fresh(f.next)}
+}
+
+// Reduces items of given iterator into initial accumulator using reducer.
+func Reduce[Acc, T any](rf Reducer[Acc, T], initial Acc, it types.Iterator[T]) Acc {
+	return ReduceSeq(rf, initial, types.Seq(it))
+}
+
+// Reduces items of given sequence into initial accumulator using a fresh
+// instance of reducer. Sequence is no longer pulled once the reducer stops.
+func ReduceSeq[Acc, T any](rf Reducer[Acc, T], initial Acc, seq iter.Seq[T]) Acc {
+	rf = Fresh(rf)
+	acc := initial
+	for item := range seq {
+		var stop bool
+		if acc, stop = rf.Step(acc, item); stop {
+			break
+		}
+	}
+	return rf.Complete(acc)
+}
+
+// Reduces items of given iterator using reducer transformed by given
+// transducer, starting from the accumulator returned by Init.
+func Transduce[Acc, A, B any](xf Transducer[Acc, A, B], rf Reducer[Acc, A], it types.Iterator[B]) Acc {
+	xrf := xf(rf)
+	return Reduce(xrf, xrf.Init(), it)
+}
+
+// Composes transducers; left transducer processes inputs first.
+func Compose[Acc, A, B, C any](left Transducer[Acc, B, C], right Transducer[Acc, A, B]) Transducer[Acc, A, C] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, C] {
+		return left(right(rf))
+	}
+}
+
+// Reducer appending items to a slice.
+func Append[T any]() Reducer[[]T, T] {
+	return NewReducer(
+		func() []T { return make([]T, 0) },
+		func(acc []T, item T) ([]T, bool) { return append(acc, item), false },
+		nil,
+	)
+}
+
+// Reducer adding items to a list. Init creates an empty immutable list
+// using given constructor.
+func Conj[T any](empty func() types.List[T]) Reducer[types.List[T], T] {
+	return NewReducer(
+		empty,
+		func(acc types.List[T], item T) (types.List[T], bool) { return acc.Add(item), false },
+		nil,
+	)
+}
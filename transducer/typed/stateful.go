@@ -0,0 +1,271 @@
+package typed
+
+import (
+	"math/rand"
+
+	"github.com/igumus/gdsa/types"
+)
+
+type dedupeStep[Acc any, A comparable] struct {
+	forward[Acc, A]
+	prior A
+	seen  bool
+}
+
+func (r *dedupeStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if r.seen && item == r.prior {
+		return acc, false
+	}
+	r.prior = item
+	r.seen = true
+	return r.next.Step(acc, item)
+}
+
+func (r *dedupeStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &dedupeStep[Acc, A]{forward: r.fresh()}
+}
+
+// Creates a transducer that removes consecutive duplicate inputs.
+func Dedupe[Acc any, A comparable]() Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &dedupeStep[Acc, A]{forward: forward[Acc, A]{next: rf}}
+	}
+}
+
+// DistinctOption configures Distinct transducer.
+type DistinctOption func(*distinctOptions)
+
+type distinctOptions struct {
+	limit int
+}
+
+// Bounds number of keys Distinct remembers. Once limit is reached, the
+// oldest key is forgotten, so an input is only guaranteed to be distinct
+// among the inputs of the last limit distinct keys.
+func WithLimit(limit int) DistinctOption {
+	return func(do *distinctOptions) {
+		do.limit = limit
+	}
+}
+
+type distinctStep[Acc, A any, K comparable] struct {
+	forward[Acc, A]
+	f     func(A) K
+	limit int
+	seen  map[K]struct{}
+	// keys in insertion order, used as a ring once limit is reached
+	order []K
+	head  int
+}
+
+func (r *distinctStep[Acc, A, K]) Step(acc Acc, item A) (Acc, bool) {
+	key := r.f(item)
+	if _, ok := r.seen[key]; ok {
+		return acc, false
+	}
+	if r.limit > 0 {
+		if len(r.order) < r.limit {
+			r.order = append(r.order, key)
+		} else {
+			delete(r.seen, r.order[r.head])
+			r.order[r.head] = key
+			r.head = (r.head + 1) % r.limit
+		}
+	}
+	r.seen[key] = struct{}{}
+	return r.next.Step(acc, item)
+}
+
+func (r *distinctStep[Acc, A, K]) Fresh() Reducer[Acc, A] {
+	return &distinctStep[Acc, A, K]{forward: r.fresh(), f: r.f, limit: r.limit, seen: make(map[K]struct{})}
+}
+
+// Creates a transducer that processes only the first input of every key
+// given function returns. Keys are remembered for the whole reducing
+// process unless bounded by WithLimit option.
+func Distinct[Acc, A any, K comparable](f func(A) K, opts ...DistinctOption) Transducer[Acc, A, A] {
+	cfg := &distinctOptions{limit: 0}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &distinctStep[Acc, A, K]{forward: forward[Acc, A]{next: rf}, f: f, limit: cfg.limit, seen: make(map[K]struct{})}
+	}
+}
+
+type interposeStep[Acc, A any] struct {
+	forward[Acc, A]
+	sep     A
+	started bool
+}
+
+func (r *interposeStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if r.started {
+		var stop bool
+		if acc, stop = r.next.Step(acc, r.sep); stop {
+			return acc, true
+		}
+	}
+	r.started = true
+	return r.next.Step(acc, item)
+}
+
+func (r *interposeStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &interposeStep[Acc, A]{forward: r.fresh(), sep: r.sep}
+}
+
+// Creates a transducer that forwards given separator between inputs.
+func Interpose[Acc, A any](sep A) Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &interposeStep[Acc, A]{forward: forward[Acc, A]{next: rf}, sep: sep}
+	}
+}
+
+type mapIndexedStep[Acc, A, B any] struct {
+	forward[Acc, A]
+	f     func(int, B) A
+	index int
+}
+
+func (r *mapIndexedStep[Acc, A, B]) Step(acc Acc, item B) (Acc, bool) {
+	index := r.index
+	r.index++
+	return r.next.Step(acc, r.f(index, item))
+}
+
+func (r *mapIndexedStep[Acc, A, B]) Fresh() Reducer[Acc, B] {
+	return &mapIndexedStep[Acc, A, B]{forward: r.fresh(), f: r.f}
+}
+
+// Creates a transducer that applies given function to the index and value
+// of each input.
+func MapIndexed[Acc, A, B any](f func(int, B) A) Transducer[Acc, A, B] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, B] {
+		return &mapIndexedStep[Acc, A, B]{forward: forward[Acc, A]{next: rf}, f: f}
+	}
+}
+
+type keepStep[Acc, A, B any] struct {
+	forward[Acc, A]
+	f func(B) (A, bool)
+}
+
+func (r *keepStep[Acc, A, B]) Step(acc Acc, item B) (Acc, bool) {
+	if kept, ok := r.f(item); ok {
+		return r.next.Step(acc, kept)
+	}
+	return acc, false
+}
+
+func (r *keepStep[Acc, A, B]) Fresh() Reducer[Acc, B] {
+	return &keepStep[Acc, A, B]{forward: r.fresh(), f: r.f}
+}
+
+// Creates a transducer that applies given function to each input,
+// processing results for which the function reports true.
+func Keep[Acc, A, B any](f func(B) (A, bool)) Transducer[Acc, A, B] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, B] {
+		return &keepStep[Acc, A, B]{forward: forward[Acc, A]{next: rf}, f: f}
+	}
+}
+
+// Creates a transducer that processes only inputs for which the predicate
+// is false.
+func Remove[Acc, A any](f func(A) bool) Transducer[Acc, A, A] {
+	return Filter[Acc](func(item A) bool {
+		return !f(item)
+	})
+}
+
+type catStep[Acc, A any] struct {
+	forward[Acc, A]
+}
+
+func (r *catStep[Acc, A]) Step(acc Acc, items types.Iterator[A]) (Acc, bool) {
+	for items.HasNext() {
+		var stop bool
+		if acc, stop = r.next.Step(acc, items.Next()); stop {
+			return acc, true
+		}
+	}
+	return acc, false
+}
+
+func (r *catStep[Acc, A]) Fresh() Reducer[Acc, types.Iterator[A]] {
+	return &catStep[Acc, A]{forward: r.fresh()}
+}
+
+// Creates a transducer that forwards every item of each input iterator.
+func Cat[Acc, A any]() Transducer[Acc, A, types.Iterator[A]] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, types.Iterator[A]] {
+		return &catStep[Acc, A]{forward: forward[Acc, A]{next: rf}}
+	}
+}
+
+// Creates a transducer that applies given function to each input and
+// forwards every item of the resulting iterator.
+func Mapcat[Acc, A, B any](f func(B) types.Iterator[A]) Transducer[Acc, A, B] {
+	return Compose(Map[Acc](f), Cat[Acc, A]())
+}
+
+type scanStep[Acc, A, B any] struct {
+	forward[Acc, A]
+	f       func(A, B) A
+	initial A
+	state   A
+}
+
+func (r *scanStep[Acc, A, B]) Step(acc Acc, item B) (Acc, bool) {
+	r.state = r.f(r.state, item)
+	return r.next.Step(acc, r.state)
+}
+
+func (r *scanStep[Acc, A, B]) Fresh() Reducer[Acc, B] {
+	return &scanStep[Acc, A, B]{forward: r.fresh(), f: r.f, initial: r.initial, state: r.initial}
+}
+
+// Creates a transducer that folds inputs into a running state starting
+// from initial, forwarding the state after each input.
+func Scan[Acc, A, B any](f func(A, B) A, initial A) Transducer[Acc, A, B] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, B] {
+		return &scanStep[Acc, A, B]{forward: forward[Acc, A]{next: rf}, f: f, initial: initial, state: initial}
+	}
+}
+
+// Creates a transducer that replaces inputs found as keys of given map with
+// the associated values.
+func Replace[Acc any, A comparable](replacements map[A]A) Transducer[Acc, A, A] {
+	return Map[Acc](func(item A) A {
+		if replacement, ok := replacements[item]; ok {
+			return replacement
+		}
+		return item
+	})
+}
+
+type randomSampleStep[Acc, A any] struct {
+	forward[Acc, A]
+	prob float64
+	seed int64
+	rnd  *rand.Rand
+}
+
+func (r *randomSampleStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if r.rnd.Float64() < r.prob {
+		return r.next.Step(acc, item)
+	}
+	return acc, false
+}
+
+func (r *randomSampleStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &randomSampleStep[Acc, A]{forward: r.fresh(), prob: r.prob, seed: r.seed, rnd: rand.New(rand.NewSource(r.seed))}
+}
+
+// Creates a transducer that processes each input with given probability.
+// Random numbers are drawn from a source seeded with given seed at the
+// start of every reducing process, so runs sample the same inputs.
+func RandomSample[Acc, A any](prob float64, seed int64) Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &randomSampleStep[Acc, A]{forward: forward[Acc, A]{next: rf}, prob: prob, seed: seed, rnd: rand.New(rand.NewSource(seed))}
+	}
+}
@@ -0,0 +1,265 @@
+package typed
+
+import (
+	"github.com/igumus/gdsa/types"
+)
+
+// Stateful steps keep their state in the reducer instance. Fresh creates a
+// new instance with initial state, which Reduce does for every run.
+
+type mapStep[Acc, A, B any] struct {
+	forward[Acc, A]
+	f func(B) A
+}
+
+func (r *mapStep[Acc, A, B]) Step(acc Acc, item B) (Acc, bool) {
+	return r.next.Step(acc, r.f(item))
+}
+
+func (r *mapStep[Acc, A, B]) Fresh() Reducer[Acc, B] {
+	return &mapStep[Acc, A, B]{forward: r.fresh(), f: r.f}
+}
+
+// Creates a transducer that transforms a reducer by applying a mapping
+// function to each input.
+func Map[Acc, A, B any](f func(B) A) Transducer[Acc, A, B] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, B] {
+		return &mapStep[Acc, A, B]{forward: forward[Acc, A]{next: rf}, f: f}
+	}
+}
+
+type filterStep[Acc, A any] struct {
+	forward[Acc, A]
+	f func(A) bool
+}
+
+func (r *filterStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if r.f(item) {
+		return r.next.Step(acc, item)
+	}
+	return acc, false
+}
+
+func (r *filterStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &filterStep[Acc, A]{forward: r.fresh(), f: r.f}
+}
+
+// Creates a transducer that transforms a reducer by processing only inputs
+// for which the predicate is true.
+func Filter[Acc, A any](f func(A) bool) Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &filterStep[Acc, A]{forward: forward[Acc, A]{next: rf}, f: f}
+	}
+}
+
+type takeStep[Acc, A any] struct {
+	forward[Acc, A]
+	n     int
+	taken int
+}
+
+func (r *takeStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if r.taken >= r.n {
+		return acc, true
+	}
+	r.taken++
+	acc, stop := r.next.Step(acc, item)
+	return acc, stop || r.taken >= r.n
+}
+
+func (r *takeStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &takeStep[Acc, A]{forward: r.fresh(), n: r.n}
+}
+
+// Creates a transducer that transforms a reducer such that it only
+// processes n inputs, then the reducing process stops.
+func Take[Acc, A any](n int) Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &takeStep[Acc, A]{forward: forward[Acc, A]{next: rf}, n: n}
+	}
+}
+
+type takeWhileStep[Acc, A any] struct {
+	forward[Acc, A]
+	f func(A) bool
+}
+
+func (r *takeWhileStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if !r.f(item) {
+		return acc, true
+	}
+	return r.next.Step(acc, item)
+}
+
+func (r *takeWhileStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &takeWhileStep[Acc, A]{forward: r.fresh(), f: r.f}
+}
+
+// Creates a transducer that transforms a reducer such that it processes
+// inputs as long as the predicate returns true.
+func TakeWhile[Acc, A any](f func(A) bool) Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &takeWhileStep[Acc, A]{forward: forward[Acc, A]{next: rf}, f: f}
+	}
+}
+
+type skipStep[Acc, A any] struct {
+	forward[Acc, A]
+	n       int
+	skipped int
+}
+
+func (r *skipStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if r.skipped < r.n {
+		r.skipped++
+		return acc, false
+	}
+	return r.next.Step(acc, item)
+}
+
+func (r *skipStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &skipStep[Acc, A]{forward: r.fresh(), n: r.n}
+}
+
+// Creates a transducer that transforms a reducer such that it skips n
+// inputs, then processes the rest of the inputs.
+func Skip[Acc, A any](n int) Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &skipStep[Acc, A]{forward: forward[Acc, A]{next: rf}, n: n}
+	}
+}
+
+type skipWhileStep[Acc, A any] struct {
+	forward[Acc, A]
+	f       func(A) bool
+	passing bool
+}
+
+func (r *skipWhileStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if !r.passing && r.f(item) {
+		return acc, false
+	}
+	r.passing = true
+	return r.next.Step(acc, item)
+}
+
+func (r *skipWhileStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &skipWhileStep[Acc, A]{forward: r.fresh(), f: r.f}
+}
+
+// Creates a transducer that transforms a reducer such that it skips inputs
+// as long as the predicate returns true, then processes the rest.
+func SkipWhile[Acc, A any](f func(A) bool) Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &skipWhileStep[Acc, A]{forward: forward[Acc, A]{next: rf}, f: f}
+	}
+}
+
+type everyNthStep[Acc, A any] struct {
+	forward[Acc, A]
+	n   int
+	nth int
+}
+
+func (r *everyNthStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	current := r.nth
+	r.nth++
+	if current%r.n == 0 {
+		return r.next.Step(acc, item)
+	}
+	return acc, false
+}
+
+func (r *everyNthStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &everyNthStep[Acc, A]{forward: r.fresh(), n: r.n}
+}
+
+// Creates a transducer that transforms a reducer such that it processes
+// every nth input.
+func EveryNth[Acc, A any](n int) Transducer[Acc, A, A] {
+	return func(rf Reducer[Acc, A]) Reducer[Acc, A] {
+		return &everyNthStep[Acc, A]{forward: forward[Acc, A]{next: rf}, n: n}
+	}
+}
+
+type partitionAllStep[Acc, A any] struct {
+	forward[Acc, types.Iterator[A]]
+	n    int
+	part []A
+}
+
+func (r *partitionAllStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	r.part = append(r.part, item)
+	if len(r.part) < r.n {
+		return acc, false
+	}
+	part := r.part
+	r.part = nil
+	return r.next.Step(acc, types.NewSliceIterator(part))
+}
+
+func (r *partitionAllStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &partitionAllStep[Acc, A]{forward: r.fresh(), n: r.n}
+}
+
+func (r *partitionAllStep[Acc, A]) Complete(acc Acc) Acc {
+	if len(r.part) > 0 {
+		part := r.part
+		r.part = nil
+		acc, _ = r.next.Step(acc, types.NewSliceIterator(part))
+	}
+	return r.next.Complete(acc)
+}
+
+// Creates a transducer that gathers inputs into partitions of given size,
+// forwarding each partition once it is full. Remaining buffered inputs are
+// forwarded when the reducing process completes.
+func PartitionAll[Acc, A any](n int) Transducer[Acc, types.Iterator[A], A] {
+	return func(rf Reducer[Acc, types.Iterator[A]]) Reducer[Acc, A] {
+		return &partitionAllStep[Acc, A]{forward: forward[Acc, types.Iterator[A]]{next: rf}, n: n}
+	}
+}
+
+type partitionByStep[Acc, A any, K comparable] struct {
+	forward[Acc, types.Iterator[A]]
+	f     func(A) K
+	part  []A
+	prior K
+}
+
+func (r *partitionByStep[Acc, A, K]) Step(acc Acc, item A) (Acc, bool) {
+	key := r.f(item)
+	if len(r.part) > 0 && key != r.prior {
+		part := r.part
+		r.part = nil
+		var stop bool
+		if acc, stop = r.next.Step(acc, types.NewSliceIterator(part)); stop {
+			return acc, true
+		}
+	}
+	r.prior = key
+	r.part = append(r.part, item)
+	return acc, false
+}
+
+func (r *partitionByStep[Acc, A, K]) Fresh() Reducer[Acc, A] {
+	return &partitionByStep[Acc, A, K]{forward: r.fresh(), f: r.f}
+}
+
+func (r *partitionByStep[Acc, A, K]) Complete(acc Acc) Acc {
+	if len(r.part) > 0 {
+		part := r.part
+		r.part = nil
+		acc, _ = r.next.Step(acc, types.NewSliceIterator(part))
+	}
+	return r.next.Complete(acc)
+}
+
+// Creates a transducer that gathers consecutive inputs for which given
+// function returns the same key into partitions, forwarding a partition
+// when the key changes. Last partition is forwarded on completion.
+func PartitionBy[Acc, A any, K comparable](f func(A) K) Transducer[Acc, types.Iterator[A], A] {
+	return func(rf Reducer[Acc, types.Iterator[A]]) Reducer[Acc, A] {
+		return &partitionByStep[Acc, A, K]{forward: forward[Acc, types.Iterator[A]]{next: rf}, f: f}
+	}
+}
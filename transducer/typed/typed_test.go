@@ -0,0 +1,234 @@
+package typed
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/igumus/gdsa/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func upTo(n int) types.Iterator[int] {
+	return types.NewFiniteRange(types.WithEnd(n))
+}
+
+func isOdd(i int) bool {
+	return i%2 != 0
+}
+
+func toArray[T any](it types.Iterator[T]) []T {
+	ret := make([]T, 0)
+	for it.HasNext() {
+		ret = append(ret, it.Next())
+	}
+	return ret
+}
+
+func TestTypedReduce(t *testing.T) {
+	sum := NewReducer(nil, func(acc, i int) (int, bool) { return acc + i, false }, nil)
+	assert.Equal(t, 45, Reduce(sum, 0, upTo(10)))
+
+	stopAt := NewReducer(nil, func(acc, i int) (int, bool) { return acc + i, i == 3 }, func(acc int) int { return -acc })
+	assert.Equal(t, -6, Reduce(stopAt, 0, types.NewInfiniteRange()))
+
+	// reducers without per-run state need only Init, Step and Complete
+	var plain Reducer[int, int] = plainSum{}
+	_, stateful := plain.(Stateful[int, int])
+	assert.False(t, stateful)
+	assert.Equal(t, 45, Reduce(plain, 0, upTo(10)))
+	assert.Equal(t, 4, Transduce(Compose(Filter[int](isOdd), Take[int, int](2)), plain, upTo(10)))
+}
+
+type plainSum struct{}
+
+func (plainSum) Init() int                      { return 0 }
+func (plainSum) Step(acc, item int) (int, bool) { return acc + item, false }
+func (plainSum) Complete(acc int) int           { return acc }
+
+func TestTypedTransduce(t *testing.T) {
+	xf := Compose(Filter[[]string](isOdd), Map[[]string](strconv.Itoa))
+	assert.Equal(t, []string{"1", "3", "5", "7", "9"}, Transduce(xf, Append[string](), upTo(10)))
+
+	assert.Equal(t, []int{0, 1, 2}, Transduce(Take[[]int, int](3), Append[int](), types.NewInfiniteRange()))
+	assert.Equal(t, []int{0, 1, 2}, Transduce(TakeWhile[[]int](func(i int) bool { return i < 3 }), Append[int](), types.NewInfiniteRange()))
+	assert.Equal(t, []int{7, 8, 9}, Transduce(Skip[[]int, int](7), Append[int](), upTo(10)))
+	assert.Equal(t, []int{7, 8, 9}, Transduce(SkipWhile[[]int](func(i int) bool { return i < 7 }), Append[int](), upTo(10)))
+	assert.Equal(t, []int{0, 3, 6, 9}, Transduce(EveryNth[[]int, int](3), Append[int](), upTo(10)))
+}
+
+func TestTypedPartition(t *testing.T) {
+	parts := Transduce(PartitionAll[[]types.Iterator[int], int](4), Append[types.Iterator[int]](), upTo(10))
+	assert.Equal(t, 3, len(parts))
+	assert.Equal(t, []int{8, 9}, toArray(parts[2]))
+
+	source := types.NewSliceIterator([]int{1, 1, 2, 3, 3})
+	parts = Transduce(PartitionBy[[]types.Iterator[int]](func(i int) int { return i }), Append[types.Iterator[int]](), source)
+	assert.Equal(t, 3, len(parts))
+	assert.Equal(t, []int{1, 1}, toArray(parts[0]))
+	assert.Equal(t, []int{3, 3}, toArray(parts[2]))
+}
+
+func windowsOf(parts []types.Iterator[int]) [][]int {
+	ret := make([][]int, 0, len(parts))
+	for _, part := range parts {
+		ret = append(ret, toArray(part))
+	}
+	return ret
+}
+
+func TestTypedWindows(t *testing.T) {
+	type windows = []types.Iterator[int]
+	parts := Transduce(SlidingWindow[windows, int](3, 1), Append[types.Iterator[int]](), upTo(5))
+	assert.Equal(t, [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}, windowsOf(parts))
+	parts = Transduce(SlidingWindow[windows, int](2, 3), Append[types.Iterator[int]](), upTo(8))
+	assert.Equal(t, [][]int{{0, 1}, {3, 4}, {6, 7}}, windowsOf(parts))
+	parts = Transduce(SlidingWindow[windows, int](4, 4), Append[types.Iterator[int]](), upTo(6))
+	assert.Equal(t, [][]int{{0, 1, 2, 3}}, windowsOf(parts))
+
+	// inputs are seconds since zero time
+	ts := func(i int) time.Time {
+		return time.Time{}.Add(time.Duration(i) * time.Second)
+	}
+	source := types.NewSliceIterator([]int{0, 3, 9, 10, 14, 25})
+	parts = Transduce(TumblingWindow[windows](10*time.Second, ts), Append[types.Iterator[int]](), source)
+	assert.Equal(t, [][]int{{0, 3, 9}, {10, 14}, {25}}, windowsOf(parts))
+
+	// keys other than time, negative ones aligned to zero as well
+	source = types.NewSliceIterator([]int{-12, -10, -1, 0, 3, 9, 10, 14, 25})
+	parts = Transduce(TumblingWindowBy[windows](10, func(i int) int { return i }), Append[types.Iterator[int]](), source)
+	assert.Equal(t, [][]int{{-12}, {-10, -1}, {0, 3, 9}, {10, 14}, {25}}, windowsOf(parts))
+	prices := types.NewSliceIterator([]float64{-0.5, 0.1, 0.4, 0.5, 1.2})
+	floats := Transduce(TumblingWindowBy[[]types.Iterator[float64]](0.5, func(f float64) float64 { return f }), Append[types.Iterator[float64]](), prices)
+	assert.Len(t, floats, 4)
+	assert.Equal(t, []float64{0.1, 0.4}, types.Collect(floats[1]))
+
+	source = types.NewSliceIterator([]int{0, 3, 9, 10, 14, 25})
+	parts = Transduce(SessionWindow[windows](4*time.Second, ts), Append[types.Iterator[int]](), source)
+	assert.Equal(t, [][]int{{0, 3}, {9, 10, 14}, {25}}, windowsOf(parts))
+
+	source = types.NewSliceIterator([]int{0, 3, 9, 10, 14, 25})
+	xf := Compose(SessionWindow[windows](4*time.Second, ts), Take[windows, types.Iterator[int]](1))
+	parts = Transduce(xf, Append[types.Iterator[int]](), source)
+	assert.Equal(t, [][]int{{0, 3}}, windowsOf(parts))
+}
+
+func TestTypedStateful(t *testing.T) {
+	ints := func(items ...int) types.Iterator[int] {
+		return types.NewSliceIterator(items)
+	}
+	assert.Equal(t, []int{1, 2, 1, 3}, Transduce(Dedupe[[]int, int](), Append[int](), ints(1, 1, 2, 1, 3, 3)))
+
+	mod3 := func(i int) int { return i % 3 }
+	assert.Equal(t, []int{0, 1, 2}, Transduce(Distinct[[]int](mod3), Append[int](), upTo(10)))
+	// only the last two keys are remembered, so 1 is forgotten once 2 and 3 are seen
+	identity := func(i int) int { return i }
+	assert.Equal(t, []int{1, 2, 3, 1}, Transduce(Distinct[[]int](identity, WithLimit(2)), Append[int](), ints(1, 2, 1, 3, 2, 1)))
+
+	assert.Equal(t, []int{1, 0, 2, 0, 3}, Transduce(Interpose[[]int](0), Append[int](), ints(1, 2, 3)))
+	assert.Equal(t, []int{1, 0}, Transduce(Compose(Interpose[[]int](0), Take[[]int, int](2)), Append[int](), ints(1, 2, 3)))
+
+	indexed := MapIndexed[[]string](func(i int, s string) string { return strconv.Itoa(i) + s })
+	assert.Equal(t, []string{"0a", "1b"}, Transduce(indexed, Append[string](), types.NewSliceIterator([]string{"a", "b"})))
+
+	parse := Keep[[]int](func(s string) (int, bool) {
+		i, err := strconv.Atoi(s)
+		return i, err == nil
+	})
+	assert.Equal(t, []int{1, 3}, Transduce(parse, Append[int](), types.NewSliceIterator([]string{"1", "x", "3"})))
+	assert.Equal(t, []int{0, 2, 4}, Transduce(Remove[[]int](isOdd), Append[int](), upTo(5)))
+
+	nested := types.NewSliceIterator([]types.Iterator[int]{ints(1, 2), ints(), ints(3)})
+	assert.Equal(t, []int{1, 2, 3}, Transduce(Cat[[]int, int](), Append[int](), nested))
+	repeat := Mapcat[[]int](func(i int) types.Iterator[int] { return ints(i, i) })
+	assert.Equal(t, []int{1, 1, 2}, Transduce(Compose(repeat, Take[[]int, int](3)), Append[int](), ints(1, 2, 3)))
+
+	sum := Scan[[]int](func(acc, i int) int { return acc + i }, 10)
+	assert.Equal(t, []int{11, 13, 16}, Transduce(sum, Append[int](), ints(1, 2, 3)))
+	rf := sum(Append[int]())
+	assert.Equal(t, Reduce(rf, nil, ints(1, 2)), Reduce(rf, nil, ints(1, 2)))
+
+	assert.Equal(t, []int{1, 20, 3}, Transduce(Replace[[]int](map[int]int{2: 20}), Append[int](), ints(1, 2, 3)))
+
+	sample := RandomSample[[]int, int](0.5, 42)(Append[int]())
+	first := Reduce(sample, nil, upTo(1000))
+	assert.Equal(t, first, Reduce(sample, nil, upTo(1000)))
+	assert.InDelta(t, 500, len(first), 100)
+	assert.Empty(t, Transduce(RandomSample[[]int, int](0, 42), Append[int](), upTo(100)))
+}
+
+func TestTypedAggregate(t *testing.T) {
+	assert.Equal(t, 45, Transduce(Map[int](func(i int) int { return i }), Sum[int](), upTo(10)))
+	assert.Equal(t, 120, Reduce(Product[int](), 1, types.NewSliceIterator([]int{1, 2, 3, 4, 5})))
+	assert.Equal(t, 5, Transduce(Filter[int](isOdd), Count[int](), upTo(10)))
+
+	source := func() types.Iterator[int] {
+		return types.NewSliceIterator([]int{3, -1, 4, 1, -5, 9})
+	}
+	assert.Equal(t, -5, Reduce(Min[int](), 0, source()))
+	assert.Equal(t, 9, Reduce(Max[int](), 100, source()))
+	assert.Equal(t, 42, Reduce(Min[int](), 42, types.NewSliceIterator([]int{})))
+	abs := func(i int) int { return max(i, -i) }
+	assert.Equal(t, -1, Reduce(MinBy(abs), 0, source()))
+	assert.Equal(t, 9, Reduce(MaxBy(abs), 0, source()))
+	words := types.NewSliceIterator([]string{"bb", "a", "cc"})
+	assert.Equal(t, "bb", Reduce(MaxBy(func(s string) int { return len(s) }), "", words))
+
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	assert.InDelta(t, 5, Reduce(Mean[float64](), 0, types.NewSliceIterator(values)), 1e-12)
+	assert.InDelta(t, 4, Reduce(Variance[float64](), 0, types.NewSliceIterator(values)), 1e-12)
+	assert.InDelta(t, 32.0/7, Reduce(SampleVariance[float64](), 0, types.NewSliceIterator(values)), 1e-12)
+	// large offset does not lose precision
+	shifted := make([]float64, len(values))
+	for i, v := range values {
+		shifted[i] = v + 1e9
+	}
+	assert.InDelta(t, 4, Reduce(Variance[float64](), 0, types.NewSliceIterator(shifted)), 1e-6)
+
+	groups := Transduce(Map[map[bool][]int](func(i int) int { return i }), GroupBy(isOdd), upTo(5))
+	assert.Equal(t, map[bool][]int{false: {0, 2, 4}, true: {1, 3}}, groups)
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, Reduce(Frequencies[string](), nil, types.NewSliceIterator([]string{"a", "b", "a"})))
+
+	assert.Equal(t, 0, Reduce(First[int](), -1, types.NewInfiniteRange()))
+	assert.Equal(t, 9, Reduce(Last[int](), -1, upTo(10)))
+
+	both := Juxt(Sum[int](), Max[int](), First[int]())
+	assert.Equal(t, []int{45, 9, 0}, Reduce(both, both.Init(), upTo(10)))
+	assert.Equal(t, []int{0, 0}, Reduce(Juxt(First[int](), First[int]()), nil, types.NewInfiniteRange()))
+
+	// initial slice is copied, so reducing from it twice gives equal results
+	initial := []int{100, 0, 0}
+	assert.Equal(t, []int{145, 9, 0}, Reduce(both, initial, upTo(10)))
+	assert.Equal(t, []int{145, 9, 0}, Reduce(both, initial, upTo(10)))
+	assert.Equal(t, []int{100, 0, 0}, initial)
+
+	stats := Tee(Count[int](), Mean[int]())
+	pair := Reduce(stats, stats.Init(), upTo(5))
+	assert.Equal(t, 5, pair.Key)
+	assert.InDelta(t, 2, pair.Value, 1e-12)
+}
+
+func TestTypedReducerReuse(t *testing.T) {
+	rf := Take[[]int, int](2)(Append[int]())
+	assert.Equal(t, []int{0, 1}, Reduce(rf, rf.Init(), upTo(10)))
+	assert.Equal(t, []int{0, 1}, Reduce(rf, rf.Init(), upTo(10)))
+}
+
+func TestTypedConcurrentReuse(t *testing.T) {
+	rf := Compose(Skip[[]types.Iterator[int], int](1), PartitionAll[[]types.Iterator[int], int](3))(Append[types.Iterator[int]]())
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				parts := Reduce(rf, rf.Init(), upTo(11))
+				assert.Equal(t, 4, len(parts))
+				assert.Equal(t, []int{1, 2, 3}, toArray(parts[0]))
+				assert.Equal(t, []int{10}, toArray(parts[3]))
+			}
+		}()
+	}
+	wg.Wait()
+}
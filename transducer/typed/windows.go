@@ -0,0 +1,134 @@
+package typed
+
+import (
+	"math"
+	"time"
+
+	"github.com/igumus/gdsa/types"
+)
+
+type slidingWindowStep[Acc, A any] struct {
+	forward[Acc, types.Iterator[A]]
+	size   int
+	step   int
+	window []A
+	skip   int
+}
+
+func (r *slidingWindowStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	if r.skip > 0 {
+		r.skip--
+		return acc, false
+	}
+	r.window = append(r.window, item)
+	if len(r.window) < r.size {
+		return acc, false
+	}
+	window := r.window
+	if r.step < r.size {
+		// emitted window is handed over downstream, so overlapping part is
+		// copied instead of shared
+		r.window = append(make([]A, 0, r.size), window[r.step:]...)
+	} else {
+		r.window = nil
+		r.skip = r.step - r.size
+	}
+	return r.next.Step(acc, types.NewSliceIterator(window))
+}
+
+func (r *slidingWindowStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &slidingWindowStep[Acc, A]{forward: r.fresh(), size: r.size, step: r.step}
+}
+
+// Creates a transducer that forwards windows of given size, starting a new
+// window every step inputs. Windows overlap when step is less than size and
+// inputs between windows are skipped when step is greater than size.
+// Incomplete windows left when the reducing process completes are dropped.
+func SlidingWindow[Acc, A any](size, step int) Transducer[Acc, types.Iterator[A], A] {
+	return func(rf Reducer[Acc, types.Iterator[A]]) Reducer[Acc, A] {
+		return &slidingWindowStep[Acc, A]{forward: forward[Acc, types.Iterator[A]]{next: rf}, size: max(size, 1), step: max(step, 1)}
+	}
+}
+
+// Creates a transducer that gathers inputs into consecutive, non-overlapping
+// windows of given duration, using given function to read timestamp of an
+// input. Windows are aligned to zero time, as by time.Time.Truncate. Last
+// window is forwarded on completion. Inputs are expected ordered by time;
+// an input which belongs to another window than the current one starts a
+// new window.
+func TumblingWindow[Acc, A any](size time.Duration, ts func(A) time.Time) Transducer[Acc, types.Iterator[A], A] {
+	return PartitionBy[Acc](func(item A) int64 {
+		return ts(item).Truncate(size).UnixNano()
+	})
+}
+
+// Creates a transducer that gathers inputs into consecutive, non-overlapping
+// windows of keys given function reads from inputs. Each window spans given
+// positive width of keys, aligned to zero, so window n holds keys in
+// [n*width, (n+1)*width). Last window is forwarded on completion. Inputs are
+// expected ordered by key, as for TumblingWindow.
+func TumblingWindowBy[Acc, A any, K types.Number](width K, key func(A) K) Transducer[Acc, types.Iterator[A], A] {
+	return PartitionBy[Acc](func(item A) K {
+		return windowOf(key(item), width)
+	})
+}
+
+// Returns index of the window of given width holding key, rounding towards
+// negative infinity so negative keys are aligned to zero too.
+func windowOf[K types.Number](key, width K) K {
+	if one, two := K(1), K(2); one/two != 0 {
+		return K(math.Floor(float64(key) / float64(width)))
+	}
+	n := key / width
+	if n*width > key {
+		n--
+	}
+	return n
+}
+
+type sessionWindowStep[Acc, A any] struct {
+	forward[Acc, types.Iterator[A]]
+	gap     time.Duration
+	ts      func(A) time.Time
+	session []A
+	last    time.Time
+}
+
+func (r *sessionWindowStep[Acc, A]) Step(acc Acc, item A) (Acc, bool) {
+	current := r.ts(item)
+	if len(r.session) > 0 && (current.Sub(r.last) > r.gap || current.Before(r.last)) {
+		session := r.session
+		r.session = nil
+		var stop bool
+		if acc, stop = r.next.Step(acc, types.NewSliceIterator(session)); stop {
+			return acc, true
+		}
+	}
+	r.last = current
+	r.session = append(r.session, item)
+	return acc, false
+}
+
+func (r *sessionWindowStep[Acc, A]) Fresh() Reducer[Acc, A] {
+	return &sessionWindowStep[Acc, A]{forward: r.fresh(), gap: r.gap, ts: r.ts}
+}
+
+func (r *sessionWindowStep[Acc, A]) Complete(acc Acc) Acc {
+	if len(r.session) > 0 {
+		session := r.session
+		r.session = nil
+		acc, _ = r.next.Step(acc, types.NewSliceIterator(session))
+	}
+	return r.next.Complete(acc)
+}
+
+// Creates a transducer that gathers inputs into sessions, using given
+// function to read timestamp of an input. A session ends when the next
+// input is more than gap later than the previous one, or earlier than it,
+// as inputs are expected ordered by time. Last session is forwarded on
+// completion.
+func SessionWindow[Acc, A any](gap time.Duration, ts func(A) time.Time) Transducer[Acc, types.Iterator[A], A] {
+	return func(rf Reducer[Acc, types.Iterator[A]]) Reducer[Acc, A] {
+		return &sessionWindowStep[Acc, A]{forward: forward[Acc, types.Iterator[A]]{next: rf}, gap: gap, ts: ts}
+	}
+}
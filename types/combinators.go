@@ -0,0 +1,304 @@
+package types
+
+import "iter"
+
+// Combinators below wrap iterators lazily: source iterators are advanced
+// only when the returned iterator is asked for more items, at most one
+// item ahead of the consumer.
+
+// PeekableIterator is an iterator which can return its next item without
+// consuming it.
+type PeekableIterator[T any] interface {
+	Iterator[T]
+	Peek() (T, bool)
+}
+
+// fetchIterator turns a function producing the next item into an iterator,
+// buffering a single item so HasNext can be answered.
+type fetchIterator[T any] struct {
+	fetch   func() (T, bool)
+	value   T
+	fetched bool
+	done    bool
+}
+
+func newFetchIterator[T any](fetch func() (T, bool)) *fetchIterator[T] {
+	return &fetchIterator[T]{fetch: fetch}
+}
+
+func (i *fetchIterator[T]) HasNext() bool {
+	if !i.fetched && !i.done {
+		var ok bool
+		if i.value, ok = i.fetch(); ok {
+			i.fetched = true
+		} else {
+			i.done = true
+			// release the closure, as it may hold on to sources
+			i.fetch = nil
+		}
+	}
+	return i.fetched
+}
+
+func (i *fetchIterator[T]) Next() T {
+	var ret T
+	if i.HasNext() {
+		ret = i.value
+		i.value, i.fetched = *new(T), false
+	}
+	return ret
+}
+
+func (i *fetchIterator[T]) Peek() (T, bool) {
+	if i.HasNext() {
+		return i.value, true
+	}
+	var ret T
+	return ret, false
+}
+
+// All returns a sequence over the remaining items of the iterator.
+func (i *fetchIterator[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i.HasNext() {
+			if !yield(i.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// Returns next item of given iterator, or false when it is exhausted.
+func pull[T any](it Iterator[T]) (T, bool) {
+	var ret T
+	if it == nil || !it.HasNext() {
+		return ret, false
+	}
+	return it.Next(), true
+}
+
+// Returns an iterator over the results of applying f to the items.
+func Map[T, R any](it Iterator[T], f func(T) R) Iterator[R] {
+	return newFetchIterator(func() (R, bool) {
+		var ret R
+		item, ok := pull(it)
+		if ok {
+			ret = f(item)
+		}
+		return ret, ok
+	})
+}
+
+// Returns an iterator over the items for which predicate returns true.
+func Filter[T any](it Iterator[T], f func(T) bool) Iterator[T] {
+	return newFetchIterator(func() (T, bool) {
+		for {
+			item, ok := pull(it)
+			if !ok || f(item) {
+				return item, ok
+			}
+		}
+	})
+}
+
+// Returns an iterator over the items of given iterators, one after another.
+func Chain[T any](its ...Iterator[T]) Iterator[T] {
+	return newFetchIterator(func() (T, bool) {
+		for len(its) > 0 {
+			if item, ok := pull(its[0]); ok {
+				return item, true
+			}
+			its = its[1:]
+		}
+		var ret T
+		return ret, false
+	})
+}
+
+// Returns an iterator over pairs of items at the same position of both
+// iterators. It ends with the shorter iterator; b is not advanced once a is
+// exhausted.
+func Zip[A, B any](a Iterator[A], b Iterator[B]) Iterator[Pair[A, B]] {
+	return newFetchIterator(func() (Pair[A, B], bool) {
+		var ret Pair[A, B]
+		left, ok := pull(a)
+		if !ok {
+			return ret, false
+		}
+		right, ok := pull(b)
+		if !ok {
+			return ret, false
+		}
+		return Pair[A, B]{Key: left, Value: right}, true
+	})
+}
+
+// Returns an iterator over pairs of items at the same position of both
+// iterators. It ends with the longer iterator, filling missing items of the
+// shorter one with given fill values.
+func ZipLongest[A, B any](a Iterator[A], b Iterator[B], fillA A, fillB B) Iterator[Pair[A, B]] {
+	return newFetchIterator(func() (Pair[A, B], bool) {
+		left, leftOk := pull(a)
+		right, rightOk := pull(b)
+		if !leftOk {
+			left = fillA
+		}
+		if !rightOk {
+			right = fillB
+		}
+		return Pair[A, B]{Key: left, Value: right}, leftOk || rightOk
+	})
+}
+
+// Returns an iterator over pairs of item positions, starting from zero, and
+// items.
+func Enumerate[T any](it Iterator[T]) Iterator[Pair[int, T]] {
+	i := 0
+	return Map(it, func(item T) Pair[int, T] {
+		ret := Pair[int, T]{Key: i, Value: item}
+		i++
+		return ret
+	})
+}
+
+// Returns an iterator over the first n items.
+func Take[T any](it Iterator[T], n int) Iterator[T] {
+	return newFetchIterator(func() (T, bool) {
+		if n <= 0 {
+			var ret T
+			return ret, false
+		}
+		n--
+		return pull(it)
+	})
+}
+
+// Returns an iterator over the items after the first n. Items are skipped
+// when the returned iterator is first used.
+func Skip[T any](it Iterator[T], n int) Iterator[T] {
+	return newFetchIterator(func() (T, bool) {
+		for ; n > 0; n-- {
+			if _, ok := pull(it); !ok {
+				break
+			}
+		}
+		return pull(it)
+	})
+}
+
+// Returns an iterator repeating the items endlessly. Items of the first pass
+// are buffered to be replayed. Cycling an empty iterator is empty.
+func Cycle[T any](it Iterator[T]) Iterator[T] {
+	buffer := make([]T, 0)
+	replaying, next := false, 0
+	return newFetchIterator(func() (T, bool) {
+		if !replaying {
+			if item, ok := pull(it); ok {
+				buffer = append(buffer, item)
+				return item, true
+			}
+			replaying = true
+		}
+		if len(buffer) == 0 {
+			var ret T
+			return ret, false
+		}
+		item := buffer[next]
+		next = (next + 1) % len(buffer)
+		return item, true
+	})
+}
+
+// Returns an endless iterator over given value. Use Take to bound it.
+func Repeat[T any](v T) Iterator[T] {
+	return newFetchIterator(func() (T, bool) {
+		return v, true
+	})
+}
+
+// Returns an iterator which can peek at the next item without consuming it.
+func Peekable[T any](it Iterator[T]) PeekableIterator[T] {
+	if p, ok := it.(PeekableIterator[T]); ok {
+		return p
+	}
+	return newFetchIterator(func() (T, bool) {
+		return pull(it)
+	})
+}
+
+// Returns an iterator over slices of consecutive items of given size. Last
+// chunk holds the remaining items and may be shorter.
+func Chunk[T any](it Iterator[T], size int) Iterator[[]T] {
+	size = max(size, 1)
+	return newFetchIterator(func() ([]T, bool) {
+		chunk := make([]T, 0, size)
+		for len(chunk) < size {
+			item, ok := pull(it)
+			if !ok {
+				break
+			}
+			chunk = append(chunk, item)
+		}
+		return chunk, len(chunk) > 0
+	})
+}
+
+// Returns an iterator over the items of the inner iterators, one after
+// another.
+func Flatten[T any](it Iterator[Iterator[T]]) Iterator[T] {
+	var current Iterator[T]
+	return newFetchIterator(func() (T, bool) {
+		for {
+			if item, ok := pull(current); ok {
+				return item, true
+			}
+			var ok bool
+			if current, ok = pull(it); !ok {
+				var ret T
+				return ret, false
+			}
+		}
+	})
+}
+
+// Returns an iterator taking one item from each iterator in turn. Exhausted
+// iterators are dropped, and it ends when every iterator is exhausted.
+func Interleave[T any](its ...Iterator[T]) Iterator[T] {
+	its = append([]Iterator[T](nil), its...)
+	next := 0
+	return newFetchIterator(func() (T, bool) {
+		for len(its) > 0 {
+			i := next % len(its)
+			if item, ok := pull(its[i]); ok {
+				next = i + 1
+				return item, true
+			}
+			its = append(its[:i], its[i+1:]...)
+			next = i
+		}
+		var ret T
+		return ret, false
+	})
+}
+
+// Returns an iterator without consecutive duplicate items.
+func Dedup[T comparable](it Iterator[T]) Iterator[T] {
+	var prior T
+	started := false
+	return Filter(it, func(item T) bool {
+		if started && item == prior {
+			return false
+		}
+		prior, started = item, true
+		return true
+	})
+}
+
+// Collects the remaining items of given iterator into a slice.
+func Collect[T any](it Iterator[T]) []T {
+	ret := make([]T, 0)
+	for item := range Seq(it) {
+		ret = append(ret, item)
+	}
+	return ret
+}
@@ -0,0 +1,51 @@
+package types
+
+import "iter"
+
+// Helpers below use optional iterator capabilities when given iterator
+// provides them, reporting false otherwise.
+
+// Moves given iterator back to its first item.
+func Reset[T any](it Iterator[T]) bool {
+	if r, ok := it.(ResettableIterator[T]); ok {
+		r.Reset()
+		return true
+	}
+	if s, ok := it.(SeekableIterator[T]); ok {
+		return s.Seek(0)
+	}
+	return false
+}
+
+// Moves given iterator to given position.
+func Seek[T any](it Iterator[T], pos int) bool {
+	if s, ok := it.(SeekableIterator[T]); ok {
+		return s.Seek(pos)
+	}
+	return false
+}
+
+// Returns position of given iterator.
+func Position[T any](it Iterator[T]) (int, bool) {
+	if s, ok := it.(SeekableIterator[T]); ok {
+		return s.Position(), true
+	}
+	return 0, false
+}
+
+// Returns a sequence moving given iterator backwards, over the items before
+// its current position in reverse order. Sequence is empty when iterator
+// cannot move backwards.
+func Backward[T any](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		b, ok := it.(BidirectionalIterator[T])
+		if !ok {
+			return
+		}
+		for b.HasPrev() {
+			if !yield(b.Prev()) {
+				return
+			}
+		}
+	}
+}
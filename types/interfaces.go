@@ -1,10 +1,44 @@
 package types
 
+import "iter"
+
 type Iterator[T any] interface {
 	Next() T
 	HasNext() bool
 }
 
+// BidirectionalIterator can also move backwards. Prev returns the item
+// which the previous call to Next returned, moving the iterator back so
+// Next returns it again.
+type BidirectionalIterator[T any] interface {
+	Iterator[T]
+	Prev() T
+	HasPrev() bool
+}
+
+// ResettableIterator can be moved back to its first item.
+type ResettableIterator[T any] interface {
+	Iterator[T]
+	Reset()
+}
+
+// SeekableIterator can be moved to any position. Position is the number of
+// items before the item Next returns; Seek reports false and leaves the
+// iterator as is when position is out of bounds.
+type SeekableIterator[T any] interface {
+	Iterator[T]
+	Seek(int) bool
+	Position() int
+}
+
+// StoppableIterator holds resources until it is exhausted. Stop releases
+// them early, so consumers abandoning the iterator should call it; no more
+// items are returned afterwards. Stopping twice does nothing.
+type StoppableIterator[T any] interface {
+	Iterator[T]
+	Stop()
+}
+
 type Collection[T any] interface {
 	IsEmpty() bool
 	Count() int
@@ -13,14 +47,34 @@ type Collection[T any] interface {
 
 type List[T any] interface {
 	Collection[T]
+	Iterable[T]
 	Get() T
 	Rest() List[T]
 	Add(T) List[T]
+	Remove(T) List[T]
+	RemoveAt(int) (List[T], bool)
+	InsertAt(int, T) (List[T], bool)
+	Update(int, T) (List[T], bool)
 	Equals(List[T]) bool
 }
 
 type Indexed[T any] interface {
 	Collection[T]
-	Set(int, T)
+	Iterable[T]
+	Set(int, T) (Indexed[T], bool)
 	Get(int) (T, bool)
+	Append(T) Indexed[T]
+	Pop() Indexed[T]
+	Slice(int, int) (Indexed[T], bool)
+}
+
+type Associative[K, V any] interface {
+	IsEmpty() bool
+	Count() int
+	Contains(K) bool
+	Get(K) (V, bool)
+	Assoc(K, V) Associative[K, V]
+	Dissoc(K) Associative[K, V]
+	Iterator() Iterator[Pair[K, V]]
+	All() iter.Seq2[K, V]
 }
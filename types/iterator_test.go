@@ -1,6 +1,9 @@
 package types
 
 import (
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,6 +47,19 @@ func TestIteratorOverString(t *testing.T) {
 	}
 }
 
+func TestIteratorOverMultiByteString(t *testing.T) {
+	// iterator is sized by runes, not bytes, so it neither runs past its
+	// last rune nor stops early
+	source := "héllo, 世界"
+	iterator := NewStringIterator(source)
+	collected := make([]rune, 0)
+	for iterator.HasNext() {
+		collected = append(collected, iterator.Next())
+	}
+	assert.Equal(t, []rune(source), collected)
+	assert.Equal(t, 9, len(collected))
+}
+
 func TestIteratorOverSlice(t *testing.T) {
 	testcases := []struct {
 		name     string
@@ -97,3 +113,402 @@ func TestIteratorOverRange(t *testing.T) {
 		i++
 	}
 }
+
+func TestIteratorSeq(t *testing.T) {
+	source := []int{1, 2, 3}
+	collected := make([]int, 0)
+	for v := range Seq(NewSliceIterator(source)) {
+		collected = append(collected, v)
+	}
+	assert.Equal(t, source, collected)
+
+	indexes := make([]int, 0)
+	for i, v := range Seq2(NewStringIterator("héllo")) {
+		indexes = append(indexes, i)
+		assert.Equal(t, []rune("héllo")[i], v)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, indexes)
+
+	collected = make([]int, 0)
+	for v := range NewInfiniteRange().(Iterable[int]).All() {
+		if v == 3 {
+			break
+		}
+		collected = append(collected, v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, collected)
+}
+
+func TestIteratorFromSeq(t *testing.T) {
+	it := FromSeq(Seq(NewFiniteRange(WithEnd(3))))
+	for i := 0; i < 3; i++ {
+		assert.True(t, it.HasNext())
+		assert.True(t, it.HasNext())
+		assert.Equal(t, i, it.Next())
+	}
+	assert.False(t, it.HasNext())
+
+	pairs := FromSeq2(Seq2(NewSliceIterator([]string{"a", "b"})))
+	assert.Equal(t, Pair[int, string]{Key: 0, Value: "a"}, pairs.Next())
+	assert.Equal(t, Pair[int, string]{Key: 1, Value: "b"}, pairs.Next())
+	assert.False(t, pairs.HasNext())
+	pairs.Stop()
+
+	// stopping abandoned iterator releases the underlying sequence
+	released := false
+	naturals := FromSeq(func(yield func(int) bool) {
+		defer func() { released = true }()
+		for i := 0; yield(i); i++ {
+		}
+	})
+	assert.Equal(t, 0, naturals.Next())
+	assert.False(t, released)
+	naturals.Stop()
+	naturals.Stop()
+	assert.True(t, released)
+	assert.False(t, naturals.HasNext())
+	assert.Equal(t, 0, naturals.Next())
+}
+
+var _ List[int] = (*LazySeq[int])(nil)
+
+func TestLazySeqMemoization(t *testing.T) {
+	var calls atomic.Int32
+	next := 0
+	// infinite sequence of natural numbers
+	naturals := NewLazySeqFromFunc(func() (int, bool) {
+		calls.Add(1)
+		next++
+		return next - 1, true
+	})
+
+	for round := 0; round < 3; round++ {
+		var current List[int] = naturals
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, i, current.Get())
+			current = current.Rest()
+		}
+	}
+	assert.Equal(t, int32(5), calls.Load())
+
+	collected := make([]int, 0)
+	for v := range naturals.All() {
+		if v == 8 {
+			break
+		}
+		collected = append(collected, v)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, collected)
+	assert.Equal(t, int32(9), calls.Load())
+
+	// concurrent walks realise every cell once
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var current List[int] = naturals
+			for i := 0; i < 100; i++ {
+				assert.Equal(t, i, current.Get())
+				current = current.Rest()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(100), calls.Load())
+}
+
+func TestLazySeqFromIterator(t *testing.T) {
+	seq := NewLazySeqFromIterator(NewSliceIterator([]int{1, 2, 3}))
+	assert.False(t, seq.IsEmpty())
+	assert.Equal(t, 3, seq.Count())
+	assert.Equal(t, 3, seq.Count())
+	assert.True(t, seq.ContainsValue(2))
+	assert.False(t, seq.ContainsValue(4))
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(seq.All()))
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(Seq(NewListIterator[int](seq))))
+
+	empty := NewLazySeqFromIterator(NewSliceIterator([]int{}))
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, 0, empty.Count())
+	assert.True(t, empty.Rest().IsEmpty())
+
+	// cells are computed by hand
+	var countdown func(n int) *LazySeq[int]
+	countdown = func(n int) *LazySeq[int] {
+		return NewLazySeq(func() (int, *LazySeq[int], bool) {
+			return n, countdown(n - 1), n > 0
+		})
+	}
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(countdown(3).All()))
+}
+
+func TestLazySeqUpdates(t *testing.T) {
+	seq := NewLazySeqFromIterator(NewSliceIterator([]int{1, 2, 3, 2}))
+	items := func(l List[int]) []int {
+		return slices.Collect(l.All())
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 2}, items(seq.Add(0)))
+	assert.Equal(t, []int{1, 3, 2}, items(seq.Remove(2)))
+	assert.Equal(t, []int{2, 3, 2}, items(seq.Remove(1)))
+	assert.Equal(t, []int{1, 2, 3, 2}, items(seq.Remove(5)))
+
+	removed, ok := seq.RemoveAt(3)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 2, 3}, items(removed))
+	_, ok = seq.RemoveAt(4)
+	assert.False(t, ok)
+
+	inserted, ok := seq.InsertAt(4, 5)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 2, 3, 2, 5}, items(inserted))
+	_, ok = seq.InsertAt(5, 5)
+	assert.False(t, ok)
+
+	updated, ok := seq.Update(1, 9)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 9, 3, 2}, items(updated))
+	_, ok = seq.Update(-1, 9)
+	assert.False(t, ok)
+	assert.Equal(t, []int{1, 2, 3, 2}, items(seq))
+
+	assert.True(t, seq.Equals(NewLazySeqFromIterator(NewSliceIterator([]int{1, 2, 3, 2}))))
+	assert.False(t, seq.Equals(removed))
+	assert.False(t, removed.Equals(seq))
+
+	// updates near the head of an infinite sequence stay lazy
+	next := 0
+	naturals := NewLazySeqFromFunc(func() (int, bool) {
+		next++
+		return next - 1, true
+	})
+	updated, _ = naturals.Update(1, 42)
+	assert.Equal(t, 42, updated.Rest().Get())
+	assert.Equal(t, 2, updated.Rest().Rest().Get())
+	assert.Equal(t, 1, naturals.Remove(0).Get())
+}
+
+func TestIteratorCombinators(t *testing.T) {
+	ints := func(items ...int) Iterator[int] {
+		return NewSliceIterator(items)
+	}
+	double := func(i int) int { return i * 2 }
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	assert.Equal(t, []int{2, 4, 6}, Collect(Map(ints(1, 2, 3), double)))
+	assert.Equal(t, []int{2}, Collect(Filter(ints(1, 2, 3), isEven)))
+	assert.Equal(t, []int{1, 2, 3}, Collect(Chain(ints(1), ints(), ints(2, 3))))
+	assert.Equal(t, []int{}, Collect(Chain[int]()))
+
+	assert.Equal(t, []Pair[int, rune]{{Key: 1, Value: 'a'}, {Key: 2, Value: 'b'}}, Collect(Zip(ints(1, 2, 3), NewStringIterator("ab"))))
+	longest := Collect(ZipLongest(ints(1), NewStringIterator("ab"), 0, '-'))
+	assert.Equal(t, []Pair[int, rune]{{Key: 1, Value: 'a'}, {Key: 0, Value: 'b'}}, longest)
+	assert.Equal(t, []Pair[int, string]{{Key: 0, Value: "a"}, {Key: 1, Value: "b"}}, Collect(Enumerate(NewSliceIterator([]string{"a", "b"}))))
+
+	assert.Equal(t, []int{0, 1, 2}, Collect(Take(NewInfiniteRange(), 3)))
+	assert.Equal(t, []int{3, 4}, Collect(Skip(ints(1, 2, 3, 4), 2)))
+	assert.Equal(t, []int{}, Collect(Skip(ints(1, 2), 5)))
+	assert.Equal(t, []int{1, 2, 1, 2, 1}, Collect(Take(Cycle(ints(1, 2)), 5)))
+	assert.Equal(t, []int{}, Collect(Take(Cycle(ints()), 5)))
+	assert.Equal(t, []string{"x", "x"}, Collect(Take(Repeat("x"), 2)))
+
+	p := Peekable(ints(1, 2))
+	v, ok := p.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 1, p.Next())
+	v, _ = p.Peek()
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 2, p.Next())
+	_, ok = p.Peek()
+	assert.False(t, ok)
+	assert.Same(t, p, Peekable[int](p))
+
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, Collect(Chunk(ints(1, 2, 3, 4, 5), 2)))
+	assert.Equal(t, []int{1, 2, 3}, Collect(Flatten(NewSliceIterator([]Iterator[int]{ints(), ints(1, 2), ints(), ints(3)}))))
+	assert.Equal(t, []int{1, 4, 6, 2, 5, 7, 3}, Collect(Interleave(ints(1, 2, 3), ints(), ints(4, 5), ints(6, 7))))
+	assert.Equal(t, []int{1, 2, 1, 3}, Collect(Dedup(ints(1, 1, 2, 1, 3, 3))))
+
+	// combinators compose lazily over infinite iterators
+	pipeline := Take(Filter(Map(NewInfiniteRange(), double), func(i int) bool { return i%3 == 0 }), 3)
+	assert.Equal(t, []int{0, 6, 12}, Collect(pipeline))
+	pulled := 0
+	counting := Map(NewInfiniteRange(), func(i int) int { pulled++; return i })
+	first := Take(counting, 2)
+	assert.Equal(t, 0, pulled)
+	assert.Equal(t, []int{0, 1}, Collect(first))
+	assert.Equal(t, 2, pulled)
+}
+
+func TestIteratorCapabilities(t *testing.T) {
+	var it Iterator[int] = NewSliceIterator([]int{1, 2, 3})
+	_, ok := it.(BidirectionalIterator[int])
+	assert.True(t, ok)
+	assert.Equal(t, 1, it.Next())
+	assert.Equal(t, 2, it.Next())
+	pos, ok := Position(it)
+	assert.True(t, ok)
+	assert.Equal(t, 2, pos)
+	assert.Equal(t, []int{2, 1}, slices.Collect(Backward(it)))
+	assert.Equal(t, 1, it.Next())
+
+	assert.True(t, Seek(it, 3))
+	assert.False(t, it.HasNext())
+	assert.False(t, Seek(it, 4))
+	assert.False(t, Seek(it, -1))
+	assert.True(t, Reset(it))
+	assert.Equal(t, []int{1, 2, 3}, Collect(it))
+
+	runes := NewStringIterator("héllo")
+	assert.True(t, Seek(runes, 1))
+	assert.Equal(t, 'é', runes.Next())
+
+	r := NewFiniteRange(WithStart(10), WithEnd(15))
+	assert.Equal(t, []int{10, 11, 12}, Collect(Take(r, 3)))
+	assert.Equal(t, []int{12, 11, 10}, slices.Collect(Backward(r)))
+	assert.True(t, Seek(r, 5))
+	assert.False(t, r.HasNext())
+	assert.False(t, Seek(r, 6))
+	assert.True(t, Seek(r, 2))
+	assert.Equal(t, 12, r.Next())
+	assert.True(t, Reset(r))
+	assert.Equal(t, []int{10, 11, 12, 13, 14}, Collect(r))
+
+	// custom steps are recalculated from the start
+	doubling := NewInfiniteRange(WithStart(1), WithStepFunction(func(i int) int { return i * 2 }))
+	assert.Equal(t, []int{1, 2, 4, 8}, Collect(Take(doubling, 4)))
+	b := doubling.(BidirectionalIterator[int])
+	assert.Equal(t, 8, b.Prev())
+	assert.Equal(t, 4, b.Prev())
+	assert.Equal(t, 4, b.Next())
+	assert.True(t, Seek(doubling, 10))
+	assert.Equal(t, 1024, doubling.Next())
+
+	// forward-only iterators report missing capabilities
+	mapped := Map(NewSliceIterator([]int{1}), func(i int) int { return i })
+	assert.False(t, Reset(mapped))
+	assert.False(t, Seek(mapped, 0))
+	_, ok = Position(mapped)
+	assert.False(t, ok)
+	assert.Empty(t, slices.Collect(Backward(mapped)))
+}
+
+func TestIteratorNumericRanges(t *testing.T) {
+	desc := NewFiniteRange(WithStart(5), WithEnd(0))
+	assert.Equal(t, 5, desc.Len())
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, Collect(desc))
+	assert.Equal(t, []int{5, 3, 1}, Collect(NewRange(5, 0, WithStep(-2))))
+	assert.Equal(t, []int{5, 3, 1}, Collect(NewRange(5, 0, WithStep(2))))
+	assert.Equal(t, []int{5, 4}, Collect(NewFiniteRange(WithStart(5), WithEnd(3), WithStepFunction(func(i int) int { return i - 1 }))))
+	assert.Equal(t, []int{}, Collect(NewRange(3, 3)))
+	assert.Equal(t, []int{3}, Collect(NewRange(3, 3, WithInclusiveEnd[int]())))
+	assert.Equal(t, []int{0, 3, 6, 9}, Collect(NewRange(0, 9, WithStep(3), WithInclusiveEnd[int]())))
+	assert.Equal(t, 3, NewRange(0, 9, WithStep(3)).Len())
+	assert.Equal(t, 4, NewRange(0, 10, WithStep(3)).Len())
+	assert.Equal(t, -1, NewInfiniteRange().Len())
+
+	// unsigned ranges descend without wrapping around
+	assert.Equal(t, []uint8{10, 7, 4, 1}, Collect(NewRange[uint8](10, 0, WithStep[uint8](3))))
+	assert.Equal(t, []uint8{2, 1, 0}, Collect(NewRange[uint8](2, 0, WithInclusiveEnd[uint8]())))
+
+	floats := NewRange(0.0, 1.0, WithStep(0.1))
+	assert.Equal(t, 10, floats.Len())
+	values := Collect(floats)
+	assert.Len(t, values, 10)
+	assert.InDelta(t, 0.9, values[9], 1e-12)
+	assert.True(t, floats.Contains(0.3))
+	assert.False(t, floats.Contains(0.35))
+	assert.False(t, floats.Contains(1.0))
+	assert.Equal(t, 11, NewRange(0.0, 1.0, WithStep(0.1), WithInclusiveEnd[float64]()).Len())
+	assert.Equal(t, []float64{1, 0.5}, Collect(NewRange(1.0, 0.0, WithStep(0.5))))
+
+	r := NewRange(10, 20, WithStep(2))
+	assert.True(t, r.Contains(14))
+	assert.False(t, r.Contains(15))
+	assert.False(t, r.Contains(20))
+	assert.False(t, r.Contains(8))
+	assert.True(t, NewInfiniteRange(WithStep(-3)).Contains(-9))
+	assert.False(t, NewInfiniteRange(WithStep(-3)).Contains(9))
+
+	powers := NewInfiniteRange(WithStart(1), WithRatio(3), WithStopWhen(func(i int) bool { return i > 100 }))
+	assert.Equal(t, -1, powers.Len())
+	assert.True(t, powers.Contains(81))
+	assert.False(t, powers.Contains(243))
+	assert.False(t, powers.Contains(10))
+	assert.Equal(t, []int{1, 3, 9, 27, 81}, Collect(powers))
+	assert.Equal(t, 81, powers.Prev())
+	assert.True(t, powers.Seek(5))
+	assert.False(t, powers.Seek(6))
+
+	halves := NewRangeOf(false, WithStart(1.0), WithRatio(0.5), WithStopWhen(func(f float64) bool { return f < 0.1 }))
+	assert.Equal(t, []float64{1, 0.5, 0.25, 0.125}, Collect(halves))
+
+	bounded := NewInfiniteRange(WithStopWhen(func(i int) bool { return i*i > 10 }))
+	assert.Equal(t, []int{0, 1, 2, 3}, Collect(bounded))
+	assert.False(t, bounded.Contains(4))
+	assert.True(t, bounded.Seek(4))
+	assert.False(t, bounded.Seek(5))
+}
+
+func TestIteratorRangeEdgeCases(t *testing.T) {
+	// length and membership share the float tolerance
+	inclusive := NewRange(0.0, 0.3, WithStep(0.1), WithInclusiveEnd[float64]())
+	assert.Equal(t, 4, inclusive.Len())
+	assert.True(t, inclusive.Contains(0.3))
+	values := Collect(inclusive)
+	assert.Len(t, values, 4)
+	assert.InDelta(t, 0.3, values[3], 1e-12)
+	assert.Equal(t, 3, NewRange(0.0, 0.3, WithStep(0.1)).Len())
+
+	// distances do not overflow narrow types
+	narrow := NewRange[int8](-100, 100, WithStep[int8](50))
+	assert.Equal(t, 4, narrow.Len())
+	assert.Equal(t, []int8{-100, -50, 0, 50}, Collect(narrow))
+	assert.True(t, narrow.Contains(50))
+	assert.False(t, narrow.Contains(100))
+	assert.Equal(t, []int8{100, 0, -100}, Collect(NewRange[int8](100, -128, WithStep[int8](100))))
+	assert.Equal(t, 256, NewRange[uint8](0, 255, WithInclusiveEnd[uint8]()).Len())
+
+	// negative step does not fit ascending ranges
+	assert.Equal(t, 0, NewRange(0, 10, WithStep(-1)).Len())
+	assert.Equal(t, []int{}, Collect(NewRange(0, 10, WithStep(-1))))
+	assert.False(t, NewRange(0, 10, WithStep(-1)).Contains(0))
+
+	// step function moving away from end ends the range
+	away := NewFiniteRange(WithStart(0), WithEnd(10), WithStepFunction(func(i int) int { return i - 1 }))
+	assert.Equal(t, []int{0}, Collect(away))
+	assert.False(t, away.Contains(-1))
+	assert.True(t, away.Seek(1))
+	assert.False(t, away.Seek(2))
+	stuck := NewFiniteRange(WithEnd(10), WithStepFunction(func(i int) int { return i }))
+	assert.Equal(t, []int{0}, Collect(stuck))
+
+	// inverse step moves backwards without walking from the start
+	calls := 0
+	doubling := NewInfiniteRange(WithStart(1), WithStepFunction(func(i int) int {
+		calls++
+		return i * 2
+	}), WithInverseStep(func(i int) int { return i / 2 }))
+	assert.True(t, doubling.Seek(10))
+	calls = 0
+	assert.Equal(t, 512, doubling.Prev())
+	assert.Equal(t, 256, doubling.Prev())
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, 256, doubling.Next())
+	assert.Equal(t, 512, doubling.Next())
+
+	powers := NewRange(1, 1000, WithRatio(10))
+	assert.Equal(t, []int{1, 10, 100}, Collect(powers))
+	assert.Equal(t, 100, powers.Prev())
+	assert.Equal(t, 10, powers.Prev())
+
+	// range ended by a wrapping step moves back from its last value
+	wrapping := NewRange[int8](1, 127, WithRatio[int8](10))
+	assert.Equal(t, []int8{1, 10, 100}, Collect(wrapping))
+	assert.Equal(t, int8(100), wrapping.Prev())
+	assert.Equal(t, int8(10), wrapping.Prev())
+	assert.Equal(t, int8(1), wrapping.Prev())
+	assert.False(t, wrapping.HasPrev())
+	assert.Equal(t, []int8{1, 10, 100}, Collect(wrapping))
+}
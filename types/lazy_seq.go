@@ -0,0 +1,232 @@
+package types
+
+import (
+	"iter"
+	"sync"
+)
+
+// LazySeq is an immutable list whose cells are computed on demand. Every
+// cell is backed by a thunk which runs at most once; its result is
+// memoised, so walking the sequence with Get and Rest any number of times,
+// even from several goroutines, computes each item only once.
+//
+// Count, ContainsValue and Equals realise the whole sequence, so they must
+// not be used on infinite sequences.
+type LazySeq[T comparable] struct {
+	once  sync.Once
+	thunk func() (T, *LazySeq[T], bool)
+	value T
+	rest  *LazySeq[T]
+	empty bool
+}
+
+// Creates lazy sequence backed by given thunk. Thunk returns the head of
+// the sequence along with the rest of it, or false when sequence is empty.
+// Nil rest is treated as empty sequence.
+func NewLazySeq[T comparable](thunk func() (T, *LazySeq[T], bool)) *LazySeq[T] {
+	return &LazySeq[T]{thunk: thunk}
+}
+
+// Creates lazy sequence over the items of given iterator. Iterator is only
+// advanced when a new cell is realised, so it must not be used elsewhere.
+func NewLazySeqFromIterator[T comparable](it Iterator[T]) *LazySeq[T] {
+	return NewLazySeqFromFunc(func() (T, bool) {
+		var ret T
+		if !it.HasNext() {
+			return ret, false
+		}
+		return it.Next(), true
+	})
+}
+
+// Creates lazy sequence over the values generated by calling given function
+// until it returns false.
+func NewLazySeqFromFunc[T comparable](gen func() (T, bool)) *LazySeq[T] {
+	return NewLazySeq(func() (T, *LazySeq[T], bool) {
+		value, ok := gen()
+		if !ok {
+			return value, nil, false
+		}
+		return value, NewLazySeqFromFunc(gen), true
+	})
+}
+
+func emptyLazySeq[T comparable]() *LazySeq[T] {
+	return &LazySeq[T]{thunk: nil}
+}
+
+// Creates realised cell holding value followed by rest.
+func consLazySeq[T comparable](value T, rest *LazySeq[T]) *LazySeq[T] {
+	ret := &LazySeq[T]{value: value, rest: rest}
+	ret.once.Do(func() {})
+	return ret
+}
+
+// Runs the thunk of the cell once, returning the cell itself.
+func (s *LazySeq[T]) realize() *LazySeq[T] {
+	if s == nil {
+		return emptyLazySeq[T]().realize()
+	}
+	s.once.Do(func() {
+		if s.thunk == nil {
+			s.empty = true
+			return
+		}
+		value, rest, ok := s.thunk()
+		// release the closure, as it may hold on to the source
+		s.thunk = nil
+		if !ok {
+			s.empty = true
+			return
+		}
+		if rest == nil {
+			rest = emptyLazySeq[T]()
+		}
+		s.value, s.rest = value, rest
+	})
+	return s
+}
+
+// Returns first i values along with the cell at index i. Reports false
+// when sequence has less than i items.
+func (s *LazySeq[T]) split(i int) ([]T, *LazySeq[T], bool) {
+	prefix := make([]T, 0, i)
+	current := s.realize()
+	for len(prefix) < i {
+		if current.empty {
+			return prefix, current, false
+		}
+		prefix = append(prefix, current.value)
+		current = current.rest.realize()
+	}
+	return prefix, current, true
+}
+
+// Prepends given values to the sequence as realised cells.
+func prependLazySeq[T comparable](rest *LazySeq[T], values []T) *LazySeq[T] {
+	for i := len(values) - 1; i >= 0; i-- {
+		rest = consLazySeq(values[i], rest)
+	}
+	return rest
+}
+
+func (s *LazySeq[T]) IsEmpty() bool {
+	return s.realize().empty
+}
+
+func (s *LazySeq[T]) Count() int {
+	ret := 0
+	for current := s.realize(); !current.empty; current = current.rest.realize() {
+		ret++
+	}
+	return ret
+}
+
+func (s *LazySeq[T]) ContainsValue(v T) bool {
+	for item := range s.All() {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns a sequence over the items, realising cells as it goes.
+func (s *LazySeq[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for current := s.realize(); !current.empty; current = current.rest.realize() {
+			if !yield(current.value) {
+				return
+			}
+		}
+	}
+}
+
+func (s *LazySeq[T]) Get() T {
+	return s.realize().value
+}
+
+func (s *LazySeq[T]) Rest() List[T] {
+	current := s.realize()
+	if current.empty {
+		return current
+	}
+	return current.rest
+}
+
+// Returns a new sequence with v prepended, without realising the sequence.
+func (s *LazySeq[T]) Add(v T) List[T] {
+	if s == nil {
+		s = emptyLazySeq[T]()
+	}
+	return consLazySeq(v, s)
+}
+
+// Returns a new sequence without the first occurrence of v. Removal happens
+// lazily, as the returned sequence is realised.
+func (s *LazySeq[T]) Remove(v T) List[T] {
+	return lazyRemove(s, v)
+}
+
+func lazyRemove[T comparable](s *LazySeq[T], v T) *LazySeq[T] {
+	return NewLazySeq(func() (T, *LazySeq[T], bool) {
+		current := s.realize()
+		if current.empty {
+			return current.value, nil, false
+		}
+		if current.value != v {
+			return current.value, lazyRemove(current.rest, v), true
+		}
+		// skip the matching cell, sharing the rest
+		rest := current.rest.realize()
+		return rest.value, rest.rest, !rest.empty
+	})
+}
+
+// Returns a new sequence without the item at index i. Items before the
+// index are realised and copied, the rest is shared.
+func (s *LazySeq[T]) RemoveAt(i int) (List[T], bool) {
+	if i < 0 {
+		return s, false
+	}
+	prefix, current, ok := s.split(i)
+	if !ok || current.empty {
+		return s, false
+	}
+	return prependLazySeq(current.rest, prefix), true
+}
+
+// Returns a new sequence with v inserted at index i. Inserting at index
+// equal to Count appends v to the tail.
+func (s *LazySeq[T]) InsertAt(i int, v T) (List[T], bool) {
+	if i < 0 {
+		return s, false
+	}
+	prefix, current, ok := s.split(i)
+	if !ok {
+		return s, false
+	}
+	return prependLazySeq(consLazySeq(v, current), prefix), true
+}
+
+// Returns a new sequence with the item at index i replaced by v.
+func (s *LazySeq[T]) Update(i int, v T) (List[T], bool) {
+	if i < 0 {
+		return s, false
+	}
+	prefix, current, ok := s.split(i)
+	if !ok || current.empty {
+		return s, false
+	}
+	return prependLazySeq(consLazySeq(v, current.rest), prefix), true
+}
+
+func (s *LazySeq[T]) Equals(o List[T]) bool {
+	it := NewListIterator(o)
+	for item := range s.All() {
+		if !it.HasNext() || it.Next() != item {
+			return false
+		}
+	}
+	return !it.HasNext()
+}
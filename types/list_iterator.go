@@ -1,5 +1,7 @@
 package types
 
+import "iter"
+
 type listIterator[T comparable] struct {
 	current List[T]
 }
@@ -19,3 +21,14 @@ func (i *listIterator[T]) Next() T {
 	i.current = i.current.Rest()
 	return value
 }
+
+// All returns a sequence over the remaining items of the list.
+func (i *listIterator[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i.HasNext() {
+			if !yield(i.Next()) {
+				return
+			}
+		}
+	}
+}
@@ -1,20 +1,48 @@
 package types
 
-type RangeOption func(*rangeOptions)
+import (
+	"iter"
+	"math"
+)
 
-type rangeOptions struct {
-	start  int
-	end    int
-	step   func(int) int
-	finite bool
+// Range is an iterator over a sequence of numbers.
+//
+// Len returns number of values of finite arithmetic ranges, or -1 when it
+// cannot be known without walking the range. Contains reports whether the
+// range yields given value; sequences with custom steps are walked from the
+// start and are assumed to be monotonic. Finite ranges with custom steps
+// end at the first step which does not move towards end.
+type Range[T Number] interface {
+	BidirectionalIterator[T]
+	ResettableIterator[T]
+	SeekableIterator[T]
+	Len() int
+	Contains(T) bool
 }
 
-func applyRangeOptions(opts ...RangeOption) *rangeOptions {
-	ret := &rangeOptions{
-		start:  0,
-		end:    100,
-		step:   func(i int) int { return i + 1 },
-		finite: false,
+type RangeOption[T Number] func(*rangeOptions[T])
+
+type rangeOptions[T Number] struct {
+	start     T
+	end       T
+	stride    T
+	step      func(T) T
+	inverse   func(T) T
+	stop      func(T) bool
+	inclusive bool
+	finite    bool
+}
+
+func applyRangeOptions[T Number](opts ...RangeOption[T]) *rangeOptions[T] {
+	ret := &rangeOptions[T]{
+		start:     0,
+		end:       100,
+		stride:    1,
+		step:      nil,
+		inverse:   nil,
+		stop:      nil,
+		inclusive: false,
+		finite:    false,
 	}
 	for _, opt := range opts {
 		opt(ret)
@@ -22,67 +50,389 @@ func applyRangeOptions(opts ...RangeOption) *rangeOptions {
 	return ret
 }
 
-func WithStart(s int) RangeOption {
-	return func(ro *rangeOptions) {
+func WithStart[T Number](s T) RangeOption[T] {
+	return func(ro *rangeOptions[T]) {
 		ro.start = s
 	}
 }
 
-func WithEnd(e int) RangeOption {
-	return func(ro *rangeOptions) {
+func WithEnd[T Number](e T) RangeOption[T] {
+	return func(ro *rangeOptions[T]) {
 		ro.end = e
 	}
 }
 
-func WithStepFunction(f func(int) int) RangeOption {
-	return func(ro *rangeOptions) {
+// Sets difference between consecutive values. Infinite ranges descend when
+// step is negative. Finite ranges move from start towards end; positive
+// step is used in either direction, while negative step only fits ranges
+// descending from start to end, making any other range empty.
+func WithStep[T Number](step T) RangeOption[T] {
+	return func(ro *rangeOptions[T]) {
+		ro.stride = step
+		ro.step = nil
+		ro.inverse = nil
+	}
+}
+
+// Sets function calculating the next value of the range. Moving backwards
+// recalculates values from the start, unless WithInverseStep gives the
+// inverse of the step function.
+func WithStepFunction[T Number](f func(T) T) RangeOption[T] {
+	return func(ro *rangeOptions[T]) {
 		ro.step = f
+		ro.inverse = nil
 	}
 }
 
-type rangeIter struct {
-	start   int
-	end     int
-	current int
-	step    func(int) int
-	finite  bool
+// Sets function calculating the previous value of a range with step
+// function, so moving backwards takes a single call. It must be given after
+// WithStepFunction and must invert it.
+func WithInverseStep[T Number](f func(T) T) RangeOption[T] {
+	return func(ro *rangeOptions[T]) {
+		ro.inverse = f
+	}
 }
 
-func newRange(cfg *rangeOptions) *rangeIter {
-	return &rangeIter{
-		start:   cfg.start,
-		end:     cfg.end,
-		current: cfg.start,
-		step:    cfg.step,
-		finite:  cfg.finite,
+// Sets ratio between consecutive values, making a geometric sequence.
+func WithRatio[T Number](ratio T) RangeOption[T] {
+	return func(ro *rangeOptions[T]) {
+		WithStepFunction(func(v T) T {
+			return v * ratio
+		})(ro)
+		if ratio != 0 {
+			WithInverseStep(func(v T) T {
+				return v / ratio
+			})(ro)
+		}
 	}
 }
 
-func NewFiniteRange(opts ...RangeOption) Iterator[int] {
-	cfg := applyRangeOptions(opts...)
-	cfg.finite = true
-	return newRange(cfg)
+// Sets predicate ending the range at the first value it returns true for.
+// The value itself is not yielded.
+func WithStopWhen[T Number](f func(T) bool) RangeOption[T] {
+	return func(ro *rangeOptions[T]) {
+		ro.stop = f
+	}
+}
+
+// Makes end of a finite range inclusive.
+func WithInclusiveEnd[T Number]() RangeOption[T] {
+	return func(ro *rangeOptions[T]) {
+		ro.inclusive = true
+	}
+}
+
+type rangeIter[T Number] struct {
+	start      T
+	end        T
+	current    T
+	index      int
+	stride     T
+	descending bool
+	step       func(T) T
+	inverse    func(T) T
+	stop       func(T) bool
+	inclusive  bool
+	finite     bool
+	length     int
+	// position past the last value of a finite range with step function,
+	// known once a step not moving towards end is taken
+	limit int
+}
+
+func newRange[T Number](cfg *rangeOptions[T]) *rangeIter[T] {
+	ret := &rangeIter[T]{
+		start:     cfg.start,
+		end:       cfg.end,
+		current:   cfg.start,
+		index:     0,
+		stride:    cfg.stride,
+		step:      cfg.step,
+		inverse:   cfg.inverse,
+		stop:      cfg.stop,
+		inclusive: cfg.inclusive,
+		finite:    cfg.finite,
+		length:    -1,
+		limit:     -1,
+	}
+	// stride holds magnitude; direction is kept separately so unsigned
+	// ranges can descend too
+	if ret.stride < 0 {
+		ret.stride = -ret.stride
+		ret.descending = true
+	}
+	if ret.finite {
+		// negative step contradicting direction from start to end yields
+		// nothing
+		if ret.descending && ret.step == nil && ret.start < ret.end {
+			ret.length = 0
+			return ret
+		}
+		ret.descending = ret.start > ret.end
+	}
+	if ret.finite && ret.step == nil {
+		ret.length = ret.arithmeticLen()
+	}
+	return ret
 }
 
-func NewInfiniteRange(opts ...RangeOption) Iterator[int] {
+// Creates finite range of ints, from start up to end by one unless options
+// say otherwise. Range descends when start is greater than end.
+func NewFiniteRange(opts ...RangeOption[int]) Range[int] {
+	return NewRangeOf(true, opts...)
+}
+
+// Creates infinite range of ints, from start by one unless options say
+// otherwise. Stop predicate can still end the range.
+func NewInfiniteRange(opts ...RangeOption[int]) Range[int] {
+	return NewRangeOf(false, opts...)
+}
+
+// Creates range from start towards end, excluding end unless
+// WithInclusiveEnd option is given.
+func NewRange[T Number](start, end T, opts ...RangeOption[T]) Range[T] {
+	return NewRangeOf(true, append([]RangeOption[T]{WithStart(start), WithEnd(end)}, opts...)...)
+}
+
+// Creates finite or infinite range of any numeric type configured by given
+// options.
+func NewRangeOf[T Number](finite bool, opts ...RangeOption[T]) Range[T] {
 	cfg := applyRangeOptions(opts...)
-	cfg.finite = false
+	cfg.finite = finite
 	return newRange(cfg)
 }
 
-func (r *rangeIter) HasNext() bool {
+func isFloat[T Number]() bool {
+	one, two := T(1), T(2)
+	return one/two != 0
+}
+
+// Tolerance of float ranges, within which a distance counts as a whole
+// number of steps.
+const floatTolerance = 1e-9
+
+// Returns number of whole steps from one value to another lying at or after
+// it in the direction of the range, and whether the distance between them
+// is a whole number of steps. Distance is calculated in float64 or uint64,
+// so it cannot overflow T.
+func (r *rangeIter[T]) steps(from, to T) (int, bool) {
+	if isFloat[T]() {
+		q := (float64(to) - float64(from)) / float64(r.stride)
+		if r.descending {
+			q = -q
+		}
+		rounded := math.Round(q)
+		if math.Abs(q-rounded) <= floatTolerance {
+			return int(rounded), true
+		}
+		return int(math.Floor(q)), false
+	}
+	// modular arithmetic gives the distance even when signed values wrap
+	distance := uint64(to) - uint64(from)
+	if r.descending {
+		distance = uint64(from) - uint64(to)
+	}
+	stride := uint64(r.stride)
+	return int(distance / stride), distance%stride == 0
+}
+
+// Returns number of values between start and end of a finite arithmetic
+// range.
+func (r *rangeIter[T]) arithmeticLen() int {
+	if r.stride == 0 {
+		return 0
+	}
+	steps, exact := r.steps(r.start, r.end)
+	if r.inclusive || !exact {
+		steps++
+	}
+	return steps
+}
+
+// Returns value at given position.
+func (r *rangeIter[T]) valueAt(pos int) T {
+	if r.step != nil {
+		value := r.start
+		for i := 0; i < pos; i++ {
+			value = r.step(value)
+		}
+		return value
+	}
+	// multiplying instead of adding avoids accumulating float errors; for
+	// integers, wrapping intermediate results still give the right value
+	if r.descending {
+		return r.start - T(pos)*r.stride
+	}
+	return r.start + T(pos)*r.stride
+}
+
+// Reports whether given value lies before the end of the range.
+func (r *rangeIter[T]) beforeEnd(v T) bool {
 	if !r.finite {
 		return true
 	}
+	switch {
+	case r.descending && r.inclusive:
+		return v >= r.end
+	case r.descending:
+		return v > r.end
+	case r.inclusive:
+		return v <= r.end
+	default:
+		return v < r.end
+	}
+}
 
-	if r.finite && r.current < r.end {
-		return true
+// Returns value following the one at given position. Reports false when a
+// finite range with step function ends at the given value, as the step does
+// not move towards end.
+func (r *rangeIter[T]) following(pos int, v T) (T, bool) {
+	if r.step == nil {
+		return r.valueAt(pos + 1), true
 	}
-	return false
+	next := r.step(v)
+	if r.finite && (next == v || (next < v) != r.descending) {
+		if r.limit < 0 || pos+1 < r.limit {
+			r.limit = pos + 1
+		}
+		return next, false
+	}
+	return next, true
+}
+
+// Reports whether value at given position belongs to the range.
+func (r *rangeIter[T]) within(pos int, v T) bool {
+	if r.length >= 0 && pos >= r.length {
+		return false
+	}
+	if r.limit >= 0 && pos >= r.limit {
+		return false
+	}
+	if r.length < 0 && !r.beforeEnd(v) {
+		return false
+	}
+	return r.stop == nil || !r.stop(v)
+}
+
+func (r *rangeIter[T]) HasNext() bool {
+	return r.within(r.index, r.current)
 }
 
-func (r *rangeIter) Next() int {
+func (r *rangeIter[T]) Next() T {
 	temp := r.current
-	r.current = r.step(r.current)
+	r.current, _ = r.following(r.index, r.current)
+	r.index++
 	return temp
 }
+
+func (r *rangeIter[T]) Prev() T {
+	// current value can only be inverted when a real step reached it, not
+	// the step ending the range, whose result may have wrapped
+	stepped := r.limit < 0 || r.index < r.limit
+	r.index--
+	if r.step != nil && r.inverse != nil && stepped {
+		r.current = r.inverse(r.current)
+	} else {
+		r.current = r.valueAt(r.index)
+	}
+	return r.current
+}
+
+func (r *rangeIter[T]) HasPrev() bool {
+	return r.index > 0
+}
+
+func (r *rangeIter[T]) Reset() {
+	r.current = r.start
+	r.index = 0
+}
+
+func (r *rangeIter[T]) Seek(pos int) bool {
+	if pos < 0 {
+		return false
+	}
+	value := r.start
+	if r.step == nil && r.stop == nil {
+		if r.length >= 0 && pos > r.length {
+			return false
+		}
+		value = r.valueAt(pos)
+	} else {
+		for i := 0; i < pos; i++ {
+			if !r.within(i, value) {
+				return false
+			}
+			value, _ = r.following(i, value)
+		}
+	}
+	r.current = value
+	r.index = pos
+	return true
+}
+
+func (r *rangeIter[T]) Position() int {
+	return r.index
+}
+
+func (r *rangeIter[T]) Len() int {
+	if r.stop != nil {
+		return -1
+	}
+	return r.length
+}
+
+func (r *rangeIter[T]) Contains(v T) bool {
+	if r.step == nil {
+		return r.containsArithmetic(v)
+	}
+	value := r.start
+	for i := 0; r.within(i, value); i++ {
+		if value == v {
+			return true
+		}
+		next, _ := r.following(i, value)
+		// values move away from v, or do not move at all
+		if next == value || (next > value && value > v) || (next < value && value < v) {
+			return false
+		}
+		value = next
+	}
+	return false
+}
+
+func (r *rangeIter[T]) containsArithmetic(v T) bool {
+	if (r.descending && v > r.start) || (!r.descending && v < r.start) {
+		return false
+	}
+	if r.stride == 0 {
+		return v == r.start && r.within(0, v)
+	}
+	pos, exact := r.steps(r.start, v)
+	if !exact {
+		return false
+	}
+	if r.length >= 0 && pos >= r.length {
+		return false
+	}
+	if r.stop != nil {
+		// every value up to v must pass the stop predicate
+		for i := 0; i <= pos; i++ {
+			if r.stop(r.valueAt(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// All returns a sequence over the remaining values of the range. Ranging
+// over an infinite range never terminates unless the loop breaks.
+func (r *rangeIter[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for r.HasNext() {
+			if !yield(r.Next()) {
+				return
+			}
+		}
+	}
+}
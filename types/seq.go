@@ -0,0 +1,115 @@
+package types
+
+import "iter"
+
+// Iterable is implemented by types which can be ranged over using Go's
+// range-over-func statement.
+type Iterable[T any] interface {
+	All() iter.Seq[T]
+}
+
+// Pair holds a key and a value, used when bridging iter.Seq2 sequences.
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// Returns key and value of the pair, so pairs can be handled without knowing
+// their type parameters.
+func (p Pair[K, V]) Entry() (any, any) {
+	return p.Key, p.Value
+}
+
+// Seq adapts given iterator into an iter.Seq. Iterator is consumed while
+// ranging over the returned sequence.
+func Seq[T any](it Iterator[T]) iter.Seq[T] {
+	if s, ok := it.(Iterable[T]); ok {
+		return s.All()
+	}
+	return func(yield func(T) bool) {
+		for it.HasNext() {
+			if !yield(it.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 adapts given iterator into an iter.Seq2 which yields item position
+// along with the item.
+func Seq2[T any](it Iterator[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range Seq(it) {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+type seqIterator[T any] struct {
+	next    func() (T, bool)
+	stop    func()
+	value   T
+	fetched bool
+	done    bool
+}
+
+// FromSeq adapts given iter.Seq into an Iterator. Underlying sequence is
+// pulled lazily, running in a coroutine which is released as soon as it is
+// exhausted; consumers abandoning the iterator earlier must call Stop.
+func FromSeq[T any](seq iter.Seq[T]) StoppableIterator[T] {
+	next, stop := iter.Pull(seq)
+	return &seqIterator[T]{
+		next: next,
+		stop: stop,
+	}
+}
+
+// FromSeq2 adapts given iter.Seq2 into an Iterator of key/value pairs, to be
+// stopped as for FromSeq.
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) StoppableIterator[Pair[K, V]] {
+	return FromSeq(func(yield func(Pair[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(Pair[K, V]{Key: k, Value: v}) {
+				return
+			}
+		}
+	})
+}
+
+func (i *seqIterator[T]) HasNext() bool {
+	if i.done {
+		return false
+	}
+	if !i.fetched {
+		value, ok := i.next()
+		if !ok {
+			i.done = true
+			i.stop()
+			return false
+		}
+		i.value = value
+		i.fetched = true
+	}
+	return true
+}
+
+func (i *seqIterator[T]) Next() T {
+	var ret T
+	if i.HasNext() {
+		ret = i.value
+		i.fetched = false
+	}
+	return ret
+}
+
+func (i *seqIterator[T]) Stop() {
+	if !i.done {
+		i.done = true
+		i.fetched = false
+		i.stop()
+	}
+}
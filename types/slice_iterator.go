@@ -1,5 +1,7 @@
 package types
 
+import "iter"
+
 type sliceIterator[T any] struct {
 	curr   int
 	size   int
@@ -16,6 +18,42 @@ func (i *sliceIterator[T]) HasNext() bool {
 	return i.size > i.curr
 }
 
+func (i *sliceIterator[T]) Prev() T {
+	i.curr--
+	return i.source[i.curr]
+}
+
+func (i *sliceIterator[T]) HasPrev() bool {
+	return i.curr > 0
+}
+
+func (i *sliceIterator[T]) Reset() {
+	i.curr = 0
+}
+
+func (i *sliceIterator[T]) Seek(pos int) bool {
+	if pos < 0 || pos > i.size {
+		return false
+	}
+	i.curr = pos
+	return true
+}
+
+func (i *sliceIterator[T]) Position() int {
+	return i.curr
+}
+
+// All returns a sequence over the remaining items of the iterator.
+func (i *sliceIterator[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i.HasNext() {
+			if !yield(i.Next()) {
+				return
+			}
+		}
+	}
+}
+
 func NewSliceIterator[T any](src []T) Iterator[T] {
 	return &sliceIterator[T]{
 		source: src,
@@ -24,10 +62,12 @@ func NewSliceIterator[T any](src []T) Iterator[T] {
 	}
 }
 
+// Creates iterator over runes of given string.
 func NewStringIterator(src string) Iterator[rune] {
+	runes := []rune(src)
 	return &sliceIterator[rune]{
-		source: []rune(src),
+		source: runes,
 		curr:   0,
-		size:   len(src),
+		size:   len(runes),
 	}
 }
@@ -1,7 +1,9 @@
 package vector
 
+import "github.com/igumus/gdsa/types"
+
 // Checks given vector is zero vector.
-// Zero vector is a vector which all fields (x,y,angle,length) is zero
+// Zero vector is a vector which both coordinates are zero
 func IsZeroVector(v *Vector) bool {
 	return zeroVector.Equal(v)
 }
@@ -58,3 +60,253 @@ func Sub(v0, v1 *Vector) *Vector {
 	ret.Sub(v1)
 	return ret
 }
+
+// Projects v0 onto v1 vector without mutating original vectors.
+func Project(v0, v1 *Vector) *Vector {
+	ret := v0.Clone()
+	ret.Project(v1)
+	return ret
+}
+
+// Calculates rejection of v0 from v1 vector without mutating original
+// vectors.
+func Reject(v0, v1 *Vector) *Vector {
+	ret := v0.Clone()
+	ret.Reject(v1)
+	return ret
+}
+
+// Reflects v0 vector off a surface with given normal without mutating
+// original vectors.
+func Reflect(v0, normal *Vector) *Vector {
+	ret := v0.Clone()
+	ret.Reflect(normal)
+	return ret
+}
+
+// Calculates unsigned angle between two vectors.
+func AngleBetween(v0, v1 *Vector) float64 {
+	return v0.AngleBetween(v1)
+}
+
+// Calculates signed angle from v0 to v1 vector.
+func SignedAngle(v0, v1 *Vector) float64 {
+	return v0.SignedAngle(v1)
+}
+
+// Calculates perpendicular of given vector without mutating original
+// vector.
+func Perp(v0 *Vector) *Vector {
+	ret := v0.Clone()
+	ret.Perp()
+	return ret
+}
+
+// Calculates z component of the cross product of two vectors. Cross
+// calculates cross product of three dimensional vectors.
+func CrossProduct(v0, v1 *Vector) float64 {
+	return v0.Cross(v1)
+}
+
+// Clamps length of v0 vector within given bounds without mutating original
+// vector.
+func ClampLength(v0 *Vector, minLength, maxLength float64) *Vector {
+	ret := v0.Clone()
+	ret.ClampLength(minLength, maxLength)
+	return ret
+}
+
+// Limits length of v0 vector without mutating original vector.
+func Limit(v0 *Vector, maxLength float64) *Vector {
+	ret := v0.Clone()
+	ret.Limit(maxLength)
+	return ret
+}
+
+// Points v0 vector at given angle without mutating original vector.
+func SetHeading(v0 *Vector, theta float64) *Vector {
+	ret := v0.Clone()
+	ret.SetHeading(theta)
+	return ret
+}
+
+// Scales v0 vector to given length without mutating original vector.
+func SetLength(v0 *Vector, length float64) *Vector {
+	ret := v0.Clone()
+	ret.SetLength(length)
+	return ret
+}
+
+// Reverses direction of v0 vector without mutating original vector.
+func Negate(v0 *Vector) *Vector {
+	ret := v0.Clone()
+	ret.Negate()
+	return ret
+}
+
+// Calculates absolute values of v0 vector's coordinates without mutating
+// original vector.
+func Abs(v0 *Vector) *Vector {
+	ret := v0.Clone()
+	ret.Abs()
+	return ret
+}
+
+// Calculates componentwise minimum of two vectors without mutating
+// original vectors.
+func Min(v0, v1 *Vector) *Vector {
+	ret := v0.Clone()
+	ret.Min(v1)
+	return ret
+}
+
+// Calculates componentwise maximum of two vectors without mutating
+// original vectors.
+func Max(v0, v1 *Vector) *Vector {
+	ret := v0.Clone()
+	ret.Max(v1)
+	return ret
+}
+
+// Calculates the Manhattan distance between two vectors.
+func ManhattanDistance(v0, v1 *Vector) float64 {
+	return v0.ManhattanDistance(v1)
+}
+
+// Calculates the Chebyshev distance between two vectors.
+func ChebyshevDistance(v0, v1 *Vector) float64 {
+	return v0.ChebyshevDistance(v1)
+}
+
+// Checks given vector is zero vector.
+func IsZeroVector3(v *Vector3) bool {
+	return v.Equal(&Vector3{})
+}
+
+// Calculates the Euclidean distance between two vectors.
+func Distance3(v0, v1 *Vector3) float64 {
+	return v0.Distance(v1)
+}
+
+// Calculates the dot product of two vectors.
+func DotProduct3(v0, v1 *Vector3) float64 {
+	return v0.DotProduct(v1)
+}
+
+// Calculates the cross product of two vectors without mutating them.
+func Cross(v0, v1 *Vector3) *Vector3 {
+	ret := v0.Clone()
+	ret.Cross(v1)
+	return ret
+}
+
+// Rotates given vector by theta angle about axis without mutating original
+// vector.
+func RotateAround(v0, axis *Vector3, theta float64) *Vector3 {
+	ret := v0.Clone()
+	ret.RotateAround(axis, theta)
+	return ret
+}
+
+// Rotates given vector by quaternion without mutating original vector.
+func Rotate3(v0 *Vector3, q Quaternion) *Vector3 {
+	ret := v0.Clone()
+	ret.Rotate(q)
+	return ret
+}
+
+// Calculates linear interpolation (lerp) from one vector to another vector
+// without mutating vectors.
+func Lerp3(v0, v1 *Vector3, amount float64) *Vector3 {
+	ret := v0.Clone()
+	ret.Lerp(v1, amount)
+	return ret
+}
+
+// Multiplies v0 vector by factor without mutating original vector
+func Multiply3(v0 *Vector3, factor float64) *Vector3 {
+	ret := v0.Clone()
+	ret.Multiply(factor)
+	return ret
+}
+
+// Divides v0 vector by factor without mutating original vector
+func Divide3(v0 *Vector3, factor float64) *Vector3 {
+	ret := v0.Clone()
+	ret.Divide(factor)
+	return ret
+}
+
+// Normalizes v0 vector without mutating original vector
+func Normalize3(v0 *Vector3) *Vector3 {
+	ret := v0.Clone()
+	ret.Normalize()
+	return ret
+}
+
+// Adds v1 onto v0 vector without mutating originial vectors.
+func Add3(v0, v1 *Vector3) *Vector3 {
+	ret := v0.Clone()
+	ret.Add(v1)
+	return ret
+}
+
+// Substracts v1 from v0 vector without mutating original vectors.
+func Sub3(v0, v1 *Vector3) *Vector3 {
+	ret := v0.Clone()
+	ret.Sub(v1)
+	return ret
+}
+
+// Calculates the Euclidean distance between two vectors.
+func DistanceN[T types.Float](v0, v1 *VecN[T]) T {
+	return v0.Distance(v1)
+}
+
+// Calculates the dot product of two vectors.
+func DotProductN[T types.Float](v0, v1 *VecN[T]) T {
+	return v0.DotProduct(v1)
+}
+
+// Calculates linear interpolation (lerp) from one vector to another vector
+// without mutating vectors.
+func LerpN[T types.Float](v0, v1 *VecN[T], amount T) *VecN[T] {
+	ret := v0.Clone()
+	ret.Lerp(v1, amount)
+	return ret
+}
+
+// Multiplies v0 vector by factor without mutating original vector
+func MultiplyN[T types.Float](v0 *VecN[T], factor T) *VecN[T] {
+	ret := v0.Clone()
+	ret.Multiply(factor)
+	return ret
+}
+
+// Divides v0 vector by factor without mutating original vector
+func DivideN[T types.Float](v0 *VecN[T], factor T) *VecN[T] {
+	ret := v0.Clone()
+	ret.Divide(factor)
+	return ret
+}
+
+// Normalizes v0 vector without mutating original vector
+func NormalizeN[T types.Float](v0 *VecN[T]) *VecN[T] {
+	ret := v0.Clone()
+	ret.Normalize()
+	return ret
+}
+
+// Adds v1 onto v0 vector without mutating originial vectors.
+func AddN[T types.Float](v0, v1 *VecN[T]) *VecN[T] {
+	ret := v0.Clone()
+	ret.Add(v1)
+	return ret
+}
+
+// Substracts v1 from v0 vector without mutating original vectors.
+func SubN[T types.Float](v0, v1 *VecN[T]) *VecN[T] {
+	ret := v0.Clone()
+	ret.Sub(v1)
+	return ret
+}
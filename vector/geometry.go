@@ -0,0 +1,144 @@
+package vector
+
+import (
+	"math"
+)
+
+// Replaces this vector with its projection onto other vector. Projecting
+// onto zero vector yields zero vector.
+func (v *Vector) Project(onto *Vector) {
+	lengthSq := onto.DotProduct(onto)
+	if lengthSq == 0 {
+		v.set(0, 0)
+		return
+	}
+	factor := v.DotProduct(onto) / lengthSq
+	v.set(rounded(onto.x*factor), rounded(onto.y*factor))
+}
+
+// Replaces this vector with its rejection from other vector, the component
+// perpendicular to it.
+func (v *Vector) Reject(from *Vector) {
+	projection := v.Clone()
+	projection.Project(from)
+	v.set(rounded(v.x-projection.x), rounded(v.y-projection.y))
+}
+
+// Reflects this vector off a surface with given normal. Normal does not
+// need to be a unit vector; zero normal leaves the vector as is.
+func (v *Vector) Reflect(normal *Vector) {
+	lengthSq := normal.DotProduct(normal)
+	if lengthSq == 0 {
+		return
+	}
+	factor := 2 * v.DotProduct(normal) / lengthSq
+	v.set(rounded(v.x-normal.x*factor), rounded(v.y-normal.y*factor))
+}
+
+// Calculates unsigned angle between this and other vector as radian, in
+// range [0, pi]. Angle with zero vector is zero.
+func (v *Vector) AngleBetween(other *Vector) float64 {
+	lengths := math.Sqrt(v.DotProduct(v) * other.DotProduct(other))
+	if lengths == 0 {
+		return 0
+	}
+	cos := math.Max(-1, math.Min(1, v.DotProduct(other)/lengths))
+	return rounded(math.Acos(cos))
+}
+
+// Calculates angle to rotate this vector by counterclockwise to point at
+// other vector as radian, in range (-pi, pi].
+func (v *Vector) SignedAngle(other *Vector) float64 {
+	return rounded(math.Atan2(v.Cross(other), v.DotProduct(other)))
+}
+
+// Replaces this vector with its perpendicular, rotated counterclockwise by
+// a right angle.
+func (v *Vector) Perp() {
+	length := v.length
+	v.set(-v.y, v.x)
+	// swapping coordinates keeps length exactly
+	v.length = length
+}
+
+// Calculates z component of the cross product of this and other vector,
+// as if both were lying in xy plane.
+func (v *Vector) Cross(other *Vector) float64 {
+	return v.x*other.y - v.y*other.x
+}
+
+// Scales the vector so its length lies within given bounds. Zero vector is
+// left as is.
+func (v *Vector) ClampLength(minLength, maxLength float64) {
+	length := v.Length()
+	switch {
+	case length == 0:
+	case length < minLength:
+		v.SetLength(minLength)
+	case length > maxLength:
+		v.SetLength(maxLength)
+	}
+}
+
+// Scales the vector down so its length is at most given limit.
+func (v *Vector) Limit(maxLength float64) {
+	if v.Length() > maxLength {
+		v.SetLength(maxLength)
+	}
+}
+
+// Heading returns direction of the vector as radian. It is the same as
+// Angle.
+func (v *Vector) Heading() float64 {
+	return v.Angle()
+}
+
+// Points the vector at given angle, keeping its length.
+func (v *Vector) SetHeading(theta float64) {
+	v.calculateCoordinates(rounded(theta), v.Length())
+}
+
+// Scales the vector to given length, keeping its direction. Zero vector is
+// left as is, as it has no direction.
+func (v *Vector) SetLength(length float64) {
+	current := math.Sqrt(v.x*v.x + v.y*v.y)
+	if current == 0 {
+		return
+	}
+	factor := length / current
+	v.set(rounded(v.x*factor), rounded(v.y*factor))
+}
+
+// Reverses direction of the vector.
+func (v *Vector) Negate() {
+	v.scale(-1)
+}
+
+// Replaces coordinates of the vector with their absolute values.
+func (v *Vector) Abs() {
+	v.set(math.Abs(v.x), math.Abs(v.y))
+}
+
+// Replaces each coordinate of the vector with the smaller of its own and
+// other vector's.
+func (v *Vector) Min(other *Vector) {
+	v.set(math.Min(v.x, other.x), math.Min(v.y, other.y))
+}
+
+// Replaces each coordinate of the vector with the greater of its own and
+// other vector's.
+func (v *Vector) Max(other *Vector) {
+	v.set(math.Max(v.x, other.x), math.Max(v.y, other.y))
+}
+
+// Calculates the Manhattan (taxicab) distance between this vector and
+// other.
+func (v *Vector) ManhattanDistance(other *Vector) float64 {
+	return rounded(math.Abs(v.x-other.x) + math.Abs(v.y-other.y))
+}
+
+// Calculates the Chebyshev distance between this vector and other, the
+// greatest difference of coordinates.
+func (v *Vector) ChebyshevDistance(other *Vector) float64 {
+	return rounded(math.Max(math.Abs(v.x-other.x), math.Abs(v.y-other.y)))
+}
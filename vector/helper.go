@@ -18,7 +18,7 @@ func round11(val float64) float64 {
 	return round(val, 11)
 }
 
-// Calculates round11 of given values square root.
+// Calculates square root of given value, rounded by the precision policy.
 func sqrt(val float64) float64 {
-	return round11(math.Sqrt(val))
+	return rounded(math.Sqrt(val))
 }
@@ -0,0 +1,95 @@
+package vector
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Precision is a policy rounding results of vector operations. Rounding
+// hides floating point noise, e.g. cos(pi/2) being 6.1e-17 instead of zero,
+// at the cost of making results depend on the order of operations.
+type Precision func(float64) float64
+
+var (
+	// PrecisionNone keeps results as calculated.
+	PrecisionNone Precision = func(val float64) float64 {
+		return val
+	}
+
+	// PrecisionDefault rounds results to 11 decimal digits.
+	PrecisionDefault = PrecisionDigits(11)
+
+	precision atomic.Value
+)
+
+func init() {
+	precision.Store(PrecisionDefault)
+}
+
+// Creates policy rounding results to given number of decimal digits.
+func PrecisionDigits(digits int) Precision {
+	if digits == 11 {
+		return round11
+	}
+	return func(val float64) float64 {
+		return round(val, digits)
+	}
+}
+
+// Sets package wide precision policy and returns the previous one. Nil
+// restores PrecisionDefault. The policy applies to operations done after
+// the call; values computed earlier are not rounded again.
+func SetPrecision(p Precision) Precision {
+	if p == nil {
+		p = PrecisionDefault
+	}
+	return precision.Swap(p).(Precision)
+}
+
+// Returns package wide precision policy.
+func GetPrecision() Precision {
+	return precision.Load().(Precision)
+}
+
+// Rounds given value by the package wide precision policy.
+func Round(val float64) float64 {
+	return rounded(val)
+}
+
+func rounded(val float64) float64 {
+	return precision.Load().(Precision)(val)
+}
+
+// Reports whether a and b differ by at most eps.
+func approxEqual(a, b, eps float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= eps
+}
+
+// Reports whether a and b are at most ulps representable float64 values
+// apart. NaN is not equal to anything.
+func ulpEqual(a, b float64, ulps uint64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	ia, ib := orderedBits(a), orderedBits(b)
+	if ia > ib {
+		ia, ib = ib, ia
+	}
+	return uint64(ib)-uint64(ia) <= ulps
+}
+
+// Maps float64 bits to integers ordered the same way as the floats, so
+// adjacent floats map to adjacent integers across zero.
+func orderedBits(f float64) int64 {
+	bits := int64(math.Float64bits(f))
+	if bits < 0 {
+		return math.MinInt64 - bits
+	}
+	return bits
+}
@@ -0,0 +1,83 @@
+package vector
+
+import (
+	"math"
+)
+
+// Quaternion represents rotations in three dimensional space. Quaternions
+// are small values, so unlike vectors their methods return new quaternions.
+type Quaternion struct {
+	w float64
+	x float64
+	y float64
+	z float64
+}
+
+// Creates quaternion with given scalar part w and vector part x, y, z.
+func CreateQuaternion(w, x, y, z float64) Quaternion {
+	return Quaternion{w: w, x: x, y: y, z: z}
+}
+
+// Creates unit quaternion rotating by theta angle about given axis. Axis
+// does not need to be a unit vector; zero axis yields identity rotation.
+func CreateRotationQuaternion(axis *Vector3, theta float64) Quaternion {
+	length := math.Sqrt(axis.x*axis.x + axis.y*axis.y + axis.z*axis.z)
+	if length == 0 {
+		return Quaternion{w: 1}
+	}
+	half := theta / 2
+	s := math.Sin(half) / length
+	return Quaternion{
+		w: math.Cos(half),
+		x: axis.x * s,
+		y: axis.y * s,
+		z: axis.z * s,
+	}
+}
+
+func (q Quaternion) W() float64 {
+	return q.w
+}
+
+func (q Quaternion) X() float64 {
+	return q.x
+}
+
+func (q Quaternion) Y() float64 {
+	return q.y
+}
+
+func (q Quaternion) Z() float64 {
+	return q.z
+}
+
+// Calculates Hamilton product of this and other quaternion. Applying the
+// product as rotation applies other first, then this quaternion.
+func (q Quaternion) Multiply(o Quaternion) Quaternion {
+	return Quaternion{
+		w: q.w*o.w - q.x*o.x - q.y*o.y - q.z*o.z,
+		x: q.w*o.x + q.x*o.w + q.y*o.z - q.z*o.y,
+		y: q.w*o.y - q.x*o.z + q.y*o.w + q.z*o.x,
+		z: q.w*o.z + q.x*o.y - q.y*o.x + q.z*o.w,
+	}
+}
+
+// Returns conjugate of the quaternion, which is its inverse for unit
+// quaternions.
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{w: q.w, x: -q.x, y: -q.y, z: -q.z}
+}
+
+// Calculates norm of the quaternion.
+func (q Quaternion) Norm() float64 {
+	return math.Sqrt(q.w*q.w + q.x*q.x + q.y*q.y + q.z*q.z)
+}
+
+// Returns quaternion scaled to norm 1. Zero quaternion is returned as is.
+func (q Quaternion) Normalize() Quaternion {
+	n := q.Norm()
+	if n == 0 {
+		return q
+	}
+	return Quaternion{w: q.w / n, x: q.x / n, y: q.y / n, z: q.z / n}
+}
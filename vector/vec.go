@@ -8,11 +8,15 @@ var (
 	zeroVector = Create()
 )
 
+// Vector is a vector in two dimensional space. Its length and angle are
+// calculated lazily and cached; every change of coordinates goes through
+// set, which invalidates both caches, so cached values always match the
+// current coordinates.
 type Vector struct {
 	x float64
 	y float64
 
-	// cached value
+	// cached values, positive infinity when not calculated yet
 	length float64
 	angle  float64
 }
@@ -24,13 +28,7 @@ func Create() *Vector {
 // Creates vector with given x and y coordinates.
 func CreateWithPoints(x, y float64) *Vector {
 	v := Create()
-	v.x = x
-	v.y = y
-
-	if x != 0.0 && y != 0.0 {
-		v.resetAngle()
-		v.resetLength()
-	}
+	v.set(x, y)
 	return v
 }
 
@@ -42,10 +40,8 @@ func CreateUnit(angle float64) *Vector {
 
 // Creates vector according to given angle and length
 func CreateWithAngleAndLength(angle, length float64) *Vector {
-	v := &Vector{}
-	v.length = round11(length)
-	v.angle = round11(angle)
-	v.calculateCoordinates()
+	v := Create()
+	v.calculateCoordinates(rounded(angle), rounded(length))
 	return v
 }
 
@@ -60,7 +56,7 @@ func (v *Vector) Y() float64 {
 // Angle calculates angle between x and y values as radian.
 func (v *Vector) Angle() float64 {
 	if isInfinity(v.angle) {
-		v.angle = round11(math.Atan2(v.y, v.x))
+		v.angle = rounded(math.Atan2(v.y, v.x))
 	}
 	return v.angle
 }
@@ -73,42 +69,44 @@ func (v *Vector) Length() float64 {
 	return v.length
 }
 
+// Checks coordinates of both vectors are exactly equal.
 func (v *Vector) Equal(o *Vector) bool {
 	if v == nil || o == nil {
 		return false
 	}
-	return v.x == o.x && v.y == o.y && v.Angle() == o.Angle()
+	return v.x == o.x && v.y == o.y
+}
+
+// Checks coordinates of both vectors differ by at most eps.
+func (v *Vector) ApproxEqual(o *Vector, eps float64) bool {
+	if v == nil || o == nil {
+		return false
+	}
+	return approxEqual(v.x, o.x, eps) && approxEqual(v.y, o.y, eps)
+}
+
+// Checks coordinates of both vectors are at most ulps representable floats
+// apart, which scales tolerance with magnitude of the coordinates.
+func (v *Vector) ApproxEqualULP(o *Vector, ulps uint64) bool {
+	if v == nil || o == nil {
+		return false
+	}
+	return ulpEqual(v.x, o.x, ulps) && ulpEqual(v.y, o.y, ulps)
 }
 
 func (v *Vector) Clone() *Vector {
-	ret := &Vector{}
-	ret.x = v.x
-	ret.y = v.y
-	ret.length = v.Length()
-	ret.angle = v.Angle()
-	return ret
+	ret := *v
+	return &ret
 }
 
 // Adds other vector to this vector
 func (v *Vector) Add(other *Vector) {
-	v.x += other.x
-	v.y += other.y
-
-	if !zeroVector.Equal(v) {
-		v.resetLength()
-		v.resetAngle()
-	}
+	v.set(v.x+other.x, v.y+other.y)
 }
 
 // Substracts other vector to this vector
 func (v *Vector) Sub(other *Vector) {
-	v.x -= other.x
-	v.y -= other.y
-
-	if !zeroVector.Equal(v) {
-		v.resetLength()
-		v.resetAngle()
-	}
+	v.set(v.x-other.x, v.y-other.y)
 }
 
 // Multiplies the vector with given scalar factor.
@@ -121,21 +119,21 @@ func (v *Vector) Divide(factor float64) {
 	v.scale(1.0 / factor)
 }
 
-// Rotate  vector by an angle.
+// Rotate vector counterclockwise by theta angle.
 func (v *Vector) Rotate(theta float64) {
-	theta = round11(theta)
-	v.angle = theta
-	cosTheta := round11(math.Cos(theta))
-	sinTheta := round11(math.Sin(theta))
-	temp := v.x
-	v.x = round11(v.x*cosTheta - v.y*sinTheta)
-	v.y = round11(temp*sinTheta + v.y*cosTheta)
+	theta = rounded(theta)
+	cosTheta := rounded(math.Cos(theta))
+	sinTheta := rounded(math.Sin(theta))
+	v.set(rounded(v.x*cosTheta-v.y*sinTheta), rounded(v.x*sinTheta+v.y*cosTheta))
 }
 
-// Normalize the vector to length 1 (make it a unit vector).
+// Normalize the vector to length 1 (make it a unit vector). Zero vector is
+// left as is.
 func (v *Vector) Normalize() {
-	v.length = 1.0
-	v.calculateCoordinates()
+	if v.x == 0 && v.y == 0 {
+		return
+	}
+	v.calculateCoordinates(v.Angle(), 1.0)
 }
 
 // Calculates the Euclidean distance this vector and other.
@@ -152,37 +150,46 @@ func (v *Vector) DotProduct(other *Vector) float64 {
 
 // Calculates linear interpolation from the vector to other vector.
 func (v *Vector) Lerp(other *Vector, amount float64) {
-	amount = round11(amount)
-	v.x = v.x + (other.x-v.x)*amount
-	v.y = v.y + (other.y-v.y)*amount
-	v.resetAngle()
-	v.resetLength()
+	amount = rounded(amount)
+	v.set(v.x+(other.x-v.x)*amount, v.y+(other.y-v.y)*amount)
 }
 
-// Scales the vector with given factor.
-// Invalidates length cache if factor is not equals to 1.0, -1.0, 0.0
+// Scales the vector with given factor. Infinite factors are ignored.
+// Length cache survives factors 1.0 and -1.0, as they keep the length
+// exactly; angle cache survives factor 1.0 only.
 func (v *Vector) scale(factor float64) {
-	if !isInfinity(factor) {
-		factor = round11(factor)
-		v.x *= factor
-		v.y *= factor
-
-		if factor == 0.0 {
-			v.length = 0
-			v.angle = 0
-			return
-		}
-		if factor != 1.0 && factor != -1.0 {
-			v.resetLength()
-		}
+	if isInfinity(factor) {
+		return
 	}
+	factor = rounded(factor)
+	length, angle := v.length, v.angle
+	v.set(v.x*factor, v.y*factor)
+	switch factor {
+	case 1.0:
+		v.length, v.angle = length, angle
+	case -1.0:
+		v.length = length
+	}
+}
+
+// Sets coordinates of the vector, invalidating cached values. Negative
+// zeros are stored as zeros, so angle does not flip sign on them, and zero
+// vector caches zero length and angle right away.
+func (v *Vector) set(x, y float64) {
+	v.x = x + 0
+	v.y = y + 0
+	if x == 0 && y == 0 {
+		v.length = 0
+		v.angle = 0
+		return
+	}
+	v.resetLength()
+	v.resetAngle()
 }
 
-// Calculates x and y coordinates based on length and angle values
-func (v *Vector) calculateCoordinates() {
-	angle := v.Angle()
-	v.x = round11(math.Cos(angle) * v.length)
-	v.y = round11(math.Sin(angle) * v.length)
+// Calculates x and y coordinates based on given angle and length values
+func (v *Vector) calculateCoordinates(angle, length float64) {
+	v.set(rounded(math.Cos(angle)*length), rounded(math.Sin(angle)*length))
 }
 
 // Resets cached angle value
@@ -0,0 +1,170 @@
+package vector
+
+import (
+	"math"
+)
+
+// Vector3 is a vector in three dimensional space. Like Vector, its methods
+// mutate the receiver while functions in fns.go return new vectors.
+type Vector3 struct {
+	x float64
+	y float64
+	z float64
+}
+
+// Creates vector with given x, y and z coordinates.
+func CreateVector3(x, y, z float64) *Vector3 {
+	return &Vector3{x: x, y: y, z: z}
+}
+
+// Creates vector from spherical coordinates: length, polar angle theta
+// measured from the z axis, and azimuthal angle phi measured from the x
+// axis in the xy plane.
+func CreateVector3FromSpherical(length, theta, phi float64) *Vector3 {
+	sinTheta := math.Sin(theta)
+	return &Vector3{
+		x: rounded(length * sinTheta * math.Cos(phi)),
+		y: rounded(length * sinTheta * math.Sin(phi)),
+		z: rounded(length * math.Cos(theta)),
+	}
+}
+
+func (v *Vector3) X() float64 {
+	return v.x
+}
+
+func (v *Vector3) Y() float64 {
+	return v.y
+}
+
+func (v *Vector3) Z() float64 {
+	return v.z
+}
+
+// Calculates length of the vector.
+func (v *Vector3) Length() float64 {
+	return sqrt(v.x*v.x + v.y*v.y + v.z*v.z)
+}
+
+// Theta calculates polar angle between the vector and z axis as radian.
+// Zero vector has zero polar angle.
+func (v *Vector3) Theta() float64 {
+	length := math.Sqrt(v.x*v.x + v.y*v.y + v.z*v.z)
+	if length == 0 {
+		return 0
+	}
+	return rounded(math.Acos(v.z / length))
+}
+
+// Phi calculates azimuthal angle of the vector in xy plane as radian.
+func (v *Vector3) Phi() float64 {
+	return rounded(math.Atan2(v.y, v.x))
+}
+
+func (v *Vector3) Equal(o *Vector3) bool {
+	if v == nil || o == nil {
+		return false
+	}
+	return v.x == o.x && v.y == o.y && v.z == o.z
+}
+
+// Checks coordinates of both vectors differ by at most eps.
+func (v *Vector3) ApproxEqual(o *Vector3, eps float64) bool {
+	if v == nil || o == nil {
+		return false
+	}
+	return approxEqual(v.x, o.x, eps) && approxEqual(v.y, o.y, eps) && approxEqual(v.z, o.z, eps)
+}
+
+// Checks coordinates of both vectors are at most ulps representable floats
+// apart.
+func (v *Vector3) ApproxEqualULP(o *Vector3, ulps uint64) bool {
+	if v == nil || o == nil {
+		return false
+	}
+	return ulpEqual(v.x, o.x, ulps) && ulpEqual(v.y, o.y, ulps) && ulpEqual(v.z, o.z, ulps)
+}
+
+func (v *Vector3) Clone() *Vector3 {
+	return &Vector3{x: v.x, y: v.y, z: v.z}
+}
+
+// Adds other vector to this vector
+func (v *Vector3) Add(other *Vector3) {
+	v.x += other.x
+	v.y += other.y
+	v.z += other.z
+}
+
+// Substracts other vector from this vector
+func (v *Vector3) Sub(other *Vector3) {
+	v.x -= other.x
+	v.y -= other.y
+	v.z -= other.z
+}
+
+// Multiplies the vector with given scalar factor.
+func (v *Vector3) Multiply(factor float64) {
+	v.x *= factor
+	v.y *= factor
+	v.z *= factor
+}
+
+// Divides the vector with given scalar factor.
+func (v *Vector3) Divide(factor float64) {
+	v.Multiply(1.0 / factor)
+}
+
+// Normalize the vector to length 1 (make it a unit vector). Zero vector is
+// left as is.
+func (v *Vector3) Normalize() {
+	length := math.Sqrt(v.x*v.x + v.y*v.y + v.z*v.z)
+	if length == 0 {
+		return
+	}
+	v.x = rounded(v.x / length)
+	v.y = rounded(v.y / length)
+	v.z = rounded(v.z / length)
+}
+
+// Calculates the Euclidean distance this vector and other.
+func (v *Vector3) Distance(other *Vector3) float64 {
+	dx, dy, dz := v.x-other.x, v.y-other.y, v.z-other.z
+	return sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Calculates dot product of this and other vector.
+func (v *Vector3) DotProduct(other *Vector3) float64 {
+	return v.x*other.x + v.y*other.y + v.z*other.z
+}
+
+// Replaces this vector with cross product of this and other vector.
+func (v *Vector3) Cross(other *Vector3) {
+	x := v.y*other.z - v.z*other.y
+	y := v.z*other.x - v.x*other.z
+	z := v.x*other.y - v.y*other.x
+	v.x, v.y, v.z = x, y, z
+}
+
+// Calculates linear interpolation from the vector to other vector.
+func (v *Vector3) Lerp(other *Vector3, amount float64) {
+	amount = rounded(amount)
+	v.x = v.x + (other.x-v.x)*amount
+	v.y = v.y + (other.y-v.y)*amount
+	v.z = v.z + (other.z-v.z)*amount
+}
+
+// Rotates the vector by given rotation quaternion.
+func (v *Vector3) Rotate(q Quaternion) {
+	// v' = q * v * q^-1, with v as a pure quaternion
+	p := q.Multiply(Quaternion{w: 0, x: v.x, y: v.y, z: v.z}).Multiply(q.Conjugate())
+	v.x = rounded(p.x)
+	v.y = rounded(p.y)
+	v.z = rounded(p.z)
+}
+
+// Rotates the vector by theta angle about given axis, counterclockwise when
+// looking from the tip of the axis towards the origin.
+func (v *Vector3) RotateAround(axis *Vector3, theta float64) {
+	v.Rotate(CreateRotationQuaternion(axis, theta))
+}
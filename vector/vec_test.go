@@ -2,7 +2,9 @@ package vector
 
 import (
 	"math"
+	"math/rand"
 	"testing"
+	"testing/quick"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,7 +33,7 @@ func TestVectorCreation(t *testing.T) {
 
 	assert.Equal(t, v0.x, v1.x)
 	assert.Equal(t, v0.y, v1.y)
-	assert.Equal(t, v0.length, v1.length)
+	assert.Equal(t, v0.Length(), v1.Length())
 	assert.Equal(t, v0.Angle(), v1.Angle())
 }
 
@@ -127,12 +129,12 @@ func TestVectorRotate(t *testing.T) {
 
 	assert.Equal(t, expectedX, v1.x)
 	assert.Equal(t, expectedY, v1.y)
-	assert.Equal(t, v0.length, v1.length)
+	assert.Equal(t, v0.Length(), v1.Length())
 
 	v2 := Rotate(v0, angle)
 	assert.Equal(t, expectedX, v2.x)
 	assert.Equal(t, expectedY, v2.y)
-	assert.Equal(t, v0.length, v2.length)
+	assert.Equal(t, v0.Length(), v2.Length())
 }
 
 func TestVectorDotProduct(t *testing.T) {
@@ -181,6 +183,358 @@ func TestVectorAngleCalculation(t *testing.T) {
 	assert.Equal(t, 1.10714871779, v.Angle())
 }
 
+func TestVector3Operations(t *testing.T) {
+	v0 := CreateVector3(1, 2, 3)
+	v1 := CreateVector3(4, 5, 6)
+
+	assert.True(t, CreateVector3(5, 7, 9).Equal(Add3(v0, v1)))
+	assert.True(t, CreateVector3(3, 3, 3).Equal(Sub3(v1, v0)))
+	assert.True(t, CreateVector3(2, 4, 6).Equal(Multiply3(v0, 2)))
+	assert.True(t, CreateVector3(2, 2.5, 3).Equal(Divide3(v1, 2)))
+	assert.True(t, CreateVector3(2.5, 3.5, 4.5).Equal(Lerp3(v0, v1, 0.5)))
+	assert.True(t, CreateVector3(1, 2, 3).Equal(v0))
+
+	assert.Equal(t, 32.0, DotProduct3(v0, v1))
+	assert.Equal(t, sqrt(27), Distance3(v0, v1))
+	assert.Equal(t, sqrt(14), v0.Length())
+	assert.True(t, IsZeroVector3(CreateVector3(0, 0, 0)))
+	assert.False(t, IsZeroVector3(v0))
+
+	cross := Cross(v0, v1)
+	assert.True(t, CreateVector3(-3, 6, -3).Equal(cross))
+	assert.Equal(t, 0.0, cross.DotProduct(v0))
+	assert.True(t, CreateVector3(0, 0, 1).Equal(Cross(CreateVector3(1, 0, 0), CreateVector3(0, 1, 0))))
+
+	unit := Normalize3(CreateVector3(0, 3, 4))
+	assert.True(t, CreateVector3(0, 0.6, 0.8).Equal(unit))
+	assert.Equal(t, 1.0, unit.Length())
+	zero := CreateVector3(0, 0, 0)
+	zero.Normalize()
+	assert.True(t, IsZeroVector3(zero))
+}
+
+func TestVector3Spherical(t *testing.T) {
+	v := CreateVector3(0, 0, 2)
+	assert.Equal(t, 0.0, v.Theta())
+	v = CreateVector3(0, 1, 0)
+	assert.Equal(t, round11(math.Pi/2), v.Theta())
+	assert.Equal(t, round11(math.Pi/2), v.Phi())
+	assert.Equal(t, 0.0, CreateVector3(0, 0, 0).Theta())
+
+	s := CreateVector3FromSpherical(2, math.Pi/2, math.Pi)
+	assert.True(t, CreateVector3(-2, 0, 0).Equal(s))
+	back := CreateVector3FromSpherical(v.Length(), v.Theta(), v.Phi())
+	assert.True(t, v.Equal(back))
+}
+
+func TestVector3Rotation(t *testing.T) {
+	x := CreateVector3(1, 0, 0)
+	z := CreateVector3(0, 0, 1)
+	assert.True(t, CreateVector3(0, 1, 0).Equal(RotateAround(x, z, math.Pi/2)))
+	assert.True(t, CreateVector3(1, 0, 0).Equal(x))
+
+	// axis does not need to be a unit vector
+	v := CreateVector3(1, 2, 3)
+	v.RotateAround(CreateVector3(0, 0, 5), math.Pi)
+	assert.True(t, CreateVector3(-1, -2, 3).Equal(v))
+
+	// rotating about the diagonal by a third of a turn permutes the axes
+	diagonal := CreateVector3(1, 1, 1)
+	assert.True(t, CreateVector3(0, 1, 0).Equal(RotateAround(x, diagonal, 2*math.Pi/3)))
+
+	// rotations compose by multiplying quaternions
+	q0 := CreateRotationQuaternion(z, math.Pi/2)
+	q1 := CreateRotationQuaternion(x, math.Pi/2)
+	composed := Rotate3(CreateVector3(1, 0, 0), q1.Multiply(q0))
+	stepwise := Rotate3(Rotate3(CreateVector3(1, 0, 0), q0), q1)
+	assert.True(t, stepwise.Equal(composed))
+	assert.True(t, CreateVector3(0, 0, 1).Equal(composed))
+	assert.InDelta(t, 1.0, q1.Multiply(q0).Norm(), 1e-12)
+
+	length := v.Length()
+	v.RotateAround(CreateVector3(1, -2, 0.5), 1.234)
+	assert.InDelta(t, length, v.Length(), 1e-9)
+	assert.True(t, CreateVector3(1, 2, 3).Equal(RotateAround(CreateVector3(1, 2, 3), CreateVector3(0, 0, 0), 1)))
+}
+
+func TestVecN(t *testing.T) {
+	v0 := CreateVecN(1.0, 2.0, 3.0, 4.0)
+	v1 := CreateVecN(4.0, 3.0, 2.0, 1.0)
+	assert.Equal(t, 4, v0.Dim())
+	assert.True(t, CreateVecN(5.0, 5.0, 5.0, 5.0).Equal(AddN(v0, v1)))
+	assert.True(t, CreateVecN(-3.0, -1.0, 1.0, 3.0).Equal(SubN(v0, v1)))
+	assert.True(t, CreateVecN(2.0, 4.0, 6.0, 8.0).Equal(MultiplyN(v0, 2)))
+	assert.True(t, CreateVecN(0.5, 1.0, 1.5, 2.0).Equal(DivideN(v0, 2)))
+	assert.True(t, CreateVecN(2.5, 2.5, 2.5, 2.5).Equal(LerpN(v0, v1, 0.5)))
+	assert.Equal(t, []float64{1, 2, 3, 4}, v0.Components())
+	assert.Equal(t, 20.0, DotProductN(v0, v1))
+	assert.Equal(t, Round(math.Sqrt(20)), DistanceN(v0, v1))
+	assert.Equal(t, Round(math.Sqrt(30)), v0.Length())
+	assert.False(t, v0.Equal(CreateVecN(1.0, 2.0, 3.0)))
+
+	// results follow the precision policy, as for Vector3
+	unit := NormalizeN(CreateVecN(1.0, 1.0, 1.0))
+	assert.Equal(t, 0.57735026919, unit.At(0))
+	assert.Equal(t, Normalize3(CreateVector3(1, 1, 1)).X(), unit.At(0))
+	tenth := 0.1
+	noisy := CreateVecN(tenth+0.2, 1.0)
+	assert.False(t, noisy.Equal(CreateVecN(0.3, 1.0)))
+	assert.True(t, noisy.ApproxEqual(CreateVecN(0.3, 1.0), 1e-12))
+	assert.True(t, noisy.ApproxEqualULP(CreateVecN(0.3, 1.0), 1))
+	assert.False(t, noisy.ApproxEqual(CreateVecN(0.3), 1))
+	previous := SetPrecision(PrecisionNone)
+	assert.Equal(t, math.Sqrt(30), v0.Length())
+	SetPrecision(previous)
+
+	f32 := NormalizeN(CreateVecN[float32](3, 4))
+	assert.Equal(t, float32(0.6), f32.At(0))
+	assert.Equal(t, float32(0.8), f32.At(1))
+	zero := CreateZeroVecN[float32](3)
+	zero.Normalize()
+	assert.Equal(t, []float32{0, 0, 0}, zero.Components())
+
+	assert.Panics(t, func() { v0.Add(CreateVecN(1.0)) })
+}
+
+func TestVectorApproxEqual(t *testing.T) {
+	v := CreateWithPoints(0.1+0.2, 1)
+	o := CreateWithPoints(0.3, 1)
+	assert.True(t, v.ApproxEqual(o, 1e-12))
+	assert.False(t, v.ApproxEqual(CreateWithPoints(0.3001, 1), 1e-12))
+	assert.True(t, v.ApproxEqualULP(o, 1))
+	assert.False(t, CreateWithPoints(1, 1).ApproxEqualULP(CreateWithPoints(1.0000001, 1), 1000))
+	assert.True(t, CreateWithPoints(-0.0, 0).ApproxEqualULP(CreateWithPoints(0, 0), 0))
+	assert.True(t, CreateWithPoints(-5e-324, 0).ApproxEqualULP(CreateWithPoints(5e-324, 0), 2))
+	assert.False(t, CreateWithPoints(math.NaN(), 0).ApproxEqualULP(CreateWithPoints(math.NaN(), 0), 10))
+	assert.False(t, v.ApproxEqual(nil, 1))
+
+	v3 := CreateVector3(0.1+0.2, 0, 1)
+	assert.True(t, v3.ApproxEqual(CreateVector3(0.3, 0, 1), 1e-12))
+	assert.True(t, v3.ApproxEqualULP(CreateVector3(0.3, 0, 1), 1))
+	assert.False(t, v3.ApproxEqualULP(CreateVector3(0.3, 1e-300, 1), 1))
+
+	// equality ignores cached angle
+	a := CreateWithPoints(1, 1)
+	b := CreateWithPoints(1, 1)
+	a.angle = 42
+	assert.True(t, a.Equal(b))
+}
+
+func TestVectorPrecision(t *testing.T) {
+	prior := SetPrecision(PrecisionNone)
+	defer SetPrecision(prior)
+
+	v := CreateWithPoints(1, 0)
+	v.Rotate(math.Pi / 2)
+	assert.NotEqual(t, 0.0, v.X())
+	assert.True(t, v.ApproxEqual(CreateWithPoints(0, 1), 1e-15))
+	assert.Equal(t, math.Sqrt(2), CreateWithPoints(1, 1).Length())
+
+	SetPrecision(PrecisionDigits(2))
+	assert.Equal(t, 1.41, CreateWithPoints(1, 1).Length())
+	assert.Equal(t, 1.41, Round(math.Sqrt(2)))
+
+	SetPrecision(func(val float64) float64 { return math.Floor(val) })
+	assert.Equal(t, 1.0, CreateWithPoints(1, 1).Length())
+
+	SetPrecision(nil)
+	assert.Equal(t, round11(math.Sqrt(2)), CreateWithPoints(1, 1).Length())
+	v = CreateWithPoints(1, 0)
+	v.Rotate(math.Pi / 2)
+	assert.True(t, v.Equal(CreateWithPoints(0, 1)))
+}
+
+func TestVectorCacheRegressions(t *testing.T) {
+	v := CreateWithPoints(3, 0)
+	assert.Equal(t, 3.0, v.Length())
+	assert.Equal(t, 4.0, CreateWithPoints(0, -4).Length())
+
+	v = CreateWithPoints(1, 1)
+	v.Angle()
+	v.Rotate(math.Pi / 4)
+	assert.InDelta(t, math.Pi/2, v.Angle(), 1e-10)
+	v.Multiply(2)
+	v.Rotate(math.Pi / 2)
+	assert.Equal(t, -2.82842712474, v.x)
+	assert.Equal(t, 2.82842712474, v.Length())
+
+	v = CreateWithPoints(1, 0)
+	v.Angle()
+	v.Multiply(-1)
+	assert.Equal(t, round11(math.Pi), v.Angle())
+
+	v = Create()
+	v.Normalize()
+	assert.True(t, IsZeroVector(v))
+	assert.Equal(t, 0.0, v.Length())
+	v = CreateWithPoints(0, 0)
+	v.Normalize()
+	assert.True(t, v.Equal(Create()))
+}
+
+// Checks cached length and angle of given vector match values recomputed
+// from its coordinates, both before and after the caches are filled.
+func checkCache(v *Vector) bool {
+	length := sqrt(v.x*v.x + v.y*v.y)
+	angle := 0.0
+	if v.x != 0 || v.y != 0 {
+		angle = round11(math.Atan2(v.y, v.x))
+	}
+	if !isInfinity(v.length) && v.length != length {
+		return false
+	}
+	if !isInfinity(v.angle) && v.angle != angle {
+		return false
+	}
+	return v.Length() == length && v.Angle() == angle
+}
+
+func TestVectorCacheProperties(t *testing.T) {
+	coordinate := func(r *rand.Rand) float64 {
+		switch r.Intn(4) {
+		case 0:
+			return 0
+		case 1:
+			return float64(r.Intn(21) - 10)
+		default:
+			return round11((r.Float64() - 0.5) * 2000)
+		}
+	}
+	random := func(r *rand.Rand) *Vector {
+		return CreateWithPoints(coordinate(r), coordinate(r))
+	}
+	factors := []float64{0, 1, -1, 2, -0.5, 3.5, math.Inf(1), math.Inf(-1)}
+	ops := []func(*Vector, *rand.Rand) *Vector{
+		func(v *Vector, r *rand.Rand) *Vector { v.Add(random(r)); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Sub(random(r)); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Sub(v.Clone()); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Multiply(factors[r.Intn(len(factors))]); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Divide(factors[1+r.Intn(5)]); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Rotate((r.Float64() - 0.5) * 4 * math.Pi); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Normalize(); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Lerp(random(r), r.Float64()); return v },
+		func(v *Vector, r *rand.Rand) *Vector { return v.Clone() },
+		func(v *Vector, r *rand.Rand) *Vector { return Rotate(v, r.Float64()) },
+		func(v *Vector, r *rand.Rand) *Vector { ret := v.Clone(); ret.Normalize(); return ret },
+		func(v *Vector, r *rand.Rand) *Vector { return Multiply(v, factors[r.Intn(len(factors))]) },
+		func(v *Vector, r *rand.Rand) *Vector {
+			return CreateWithAngleAndLength((r.Float64()-0.5)*4*math.Pi, coordinate(r))
+		},
+		func(v *Vector, r *rand.Rand) *Vector { v.Project(random(r)); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Reject(random(r)); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Reflect(random(r)); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Perp(); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.ClampLength(1, 10); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Limit(5); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.SetHeading(r.Float64() * math.Pi); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.SetLength(coordinate(r)); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Negate(); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Abs(); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Min(random(r)); return v },
+		func(v *Vector, r *rand.Rand) *Vector { v.Max(random(r)); return v },
+	}
+
+	property := func(seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+		v := random(r)
+		for range 50 {
+			// fill caches only sometimes, so operations see both states
+			if r.Intn(2) == 0 && !checkCache(v) {
+				return false
+			}
+			v = ops[r.Intn(len(ops))](v, r)
+		}
+		return checkCache(v)
+	}
+	assert.NoError(t, quick.Check(property, &quick.Config{MaxCount: 500}))
+}
+
+func TestVectorProjection(t *testing.T) {
+	v := CreateWithPoints(3, 4)
+	x := CreateWithPoints(2, 0)
+	assert.True(t, CreateWithPoints(3, 0).Equal(Project(v, x)))
+	assert.True(t, CreateWithPoints(0, 4).Equal(Reject(v, x)))
+	assert.True(t, Create().Equal(Project(v, Create())))
+	assert.True(t, v.Equal(CreateWithPoints(3, 4)))
+
+	// projection and rejection add up to the vector
+	d := CreateWithPoints(1, 1)
+	assert.True(t, v.ApproxEqual(Add(Project(v, d), Reject(v, d)), 1e-10))
+	assert.Equal(t, 0.0, round11(Reject(v, d).DotProduct(d)))
+
+	assert.True(t, CreateWithPoints(3, -4).Equal(Reflect(v, CreateWithPoints(0, 5))))
+	assert.True(t, CreateWithPoints(-4, -3).Equal(Reflect(v, CreateWithPoints(1, 1))))
+	assert.True(t, v.Equal(Reflect(v, Create())))
+
+	v.Project(x)
+	assert.Equal(t, 3.0, v.Length())
+}
+
+func TestVectorAngles(t *testing.T) {
+	x := CreateWithPoints(1, 0)
+	y := CreateWithPoints(0, 2)
+	assert.Equal(t, round11(math.Pi/2), AngleBetween(x, y))
+	assert.Equal(t, round11(math.Pi/2), AngleBetween(y, x))
+	assert.Equal(t, round11(math.Pi), AngleBetween(x, CreateWithPoints(-3, 0)))
+	assert.Equal(t, 0.0, AngleBetween(x, Create()))
+	assert.Equal(t, 0.0, AngleBetween(CreateWithPoints(1, 1), CreateWithPoints(2, 2)))
+
+	assert.Equal(t, round11(math.Pi/2), SignedAngle(x, y))
+	assert.Equal(t, round11(-math.Pi/2), SignedAngle(y, x))
+	assert.Equal(t, round11(math.Pi), SignedAngle(x, CreateWithPoints(-1, 0)))
+
+	assert.Equal(t, 2.0, CrossProduct(x, y))
+	assert.Equal(t, -2.0, y.Cross(x))
+	assert.Equal(t, 0.0, CrossProduct(x, CreateWithPoints(5, 0)))
+
+	p := Perp(CreateWithPoints(3, 4))
+	assert.True(t, CreateWithPoints(-4, 3).Equal(p))
+	assert.Equal(t, 5.0, p.Length())
+	assert.Equal(t, 0.0, p.DotProduct(CreateWithPoints(3, 4)))
+
+	h := CreateWithPoints(0, 3)
+	assert.Equal(t, h.Angle(), h.Heading())
+	assert.True(t, CreateWithPoints(3, 0).Equal(SetHeading(h, 0)))
+	h.SetHeading(math.Pi)
+	assert.True(t, CreateWithPoints(-3, 0).Equal(h))
+	assert.Equal(t, round11(math.Pi), h.Heading())
+}
+
+func TestVectorLengthLimits(t *testing.T) {
+	v := CreateWithPoints(3, 4)
+	assert.True(t, CreateWithPoints(6, 8).Equal(SetLength(v, 10)))
+	assert.True(t, CreateWithPoints(0.6, 0.8).Equal(Limit(v, 1)))
+	assert.True(t, v.Equal(Limit(v, 10)))
+	assert.True(t, CreateWithPoints(6, 8).Equal(ClampLength(v, 10, 20)))
+	assert.True(t, CreateWithPoints(1.2, 1.6).Equal(ClampLength(v, 1, 2)))
+	assert.True(t, v.Equal(ClampLength(v, 1, 10)))
+	assert.True(t, Create().Equal(ClampLength(Create(), 1, 2)))
+	assert.True(t, Create().Equal(SetLength(Create(), 2)))
+	assert.Equal(t, 5.0, v.Length())
+
+	v.SetLength(2.5)
+	assert.Equal(t, 2.5, v.Length())
+	assert.True(t, CreateWithPoints(1.5, 2).Equal(v))
+}
+
+func TestVectorComponentwise(t *testing.T) {
+	v0 := CreateWithPoints(-1, 5)
+	v1 := CreateWithPoints(3, -2)
+	assert.True(t, CreateWithPoints(1, -5).Equal(Negate(v0)))
+	assert.True(t, CreateWithPoints(1, 5).Equal(Abs(v0)))
+	assert.True(t, CreateWithPoints(-1, -2).Equal(Min(v0, v1)))
+	assert.True(t, CreateWithPoints(3, 5).Equal(Max(v0, v1)))
+	assert.True(t, CreateWithPoints(-1, 5).Equal(v0))
+
+	assert.Equal(t, 11.0, ManhattanDistance(v0, v1))
+	assert.Equal(t, 7.0, ChebyshevDistance(v0, v1))
+	assert.Equal(t, 7.0, v1.ChebyshevDistance(v0))
+	assert.Equal(t, 0.0, ManhattanDistance(v0, v0))
+
+	v0.Negate()
+	assert.Equal(t, round11(math.Atan2(-5, 1)), v0.Angle())
+}
+
 func BenchmarkAngleCalculation(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		f := float64(i)
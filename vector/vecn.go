@@ -0,0 +1,166 @@
+package vector
+
+import (
+	"math"
+
+	"github.com/igumus/gdsa/types"
+)
+
+// VecN is a vector with any number of dimensions. Like Vector, its methods
+// mutate the receiver while functions in fns.go return new vectors, and
+// results are rounded by the package wide precision policy as for Vector.
+// Operations on vectors with different dimensions panic.
+type VecN[T types.Float] struct {
+	components []T
+}
+
+// Creates vector with given components.
+func CreateVecN[T types.Float](components ...T) *VecN[T] {
+	return &VecN[T]{components: append([]T(nil), components...)}
+}
+
+// Creates zero vector with given number of dimensions.
+func CreateZeroVecN[T types.Float](dim int) *VecN[T] {
+	return &VecN[T]{components: make([]T, dim)}
+}
+
+// Returns number of dimensions of the vector.
+func (v *VecN[T]) Dim() int {
+	return len(v.components)
+}
+
+// Returns component at given index.
+func (v *VecN[T]) At(i int) T {
+	return v.components[i]
+}
+
+// Returns copy of the components.
+func (v *VecN[T]) Components() []T {
+	return append([]T(nil), v.components...)
+}
+
+func (v *VecN[T]) mustMatch(other *VecN[T]) {
+	if len(v.components) != len(other.components) {
+		panic("vector: dimension mismatch")
+	}
+}
+
+// Calculates length of the vector.
+func (v *VecN[T]) Length() T {
+	return T(sqrt(float64(v.DotProduct(v))))
+}
+
+// Checks components of both vectors are exactly equal.
+func (v *VecN[T]) Equal(o *VecN[T]) bool {
+	if v == nil || o == nil || len(v.components) != len(o.components) {
+		return false
+	}
+	for i, c := range v.components {
+		if c != o.components[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Checks components of both vectors differ by at most eps.
+func (v *VecN[T]) ApproxEqual(o *VecN[T], eps float64) bool {
+	if v == nil || o == nil || len(v.components) != len(o.components) {
+		return false
+	}
+	for i, c := range v.components {
+		if !approxEqual(float64(c), float64(o.components[i]), eps) {
+			return false
+		}
+	}
+	return true
+}
+
+// Checks components of both vectors are at most ulps representable float64
+// values apart.
+func (v *VecN[T]) ApproxEqualULP(o *VecN[T], ulps uint64) bool {
+	if v == nil || o == nil || len(v.components) != len(o.components) {
+		return false
+	}
+	for i, c := range v.components {
+		if !ulpEqual(float64(c), float64(o.components[i]), ulps) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *VecN[T]) Clone() *VecN[T] {
+	return CreateVecN(v.components...)
+}
+
+// Adds other vector to this vector
+func (v *VecN[T]) Add(other *VecN[T]) {
+	v.mustMatch(other)
+	for i := range v.components {
+		v.components[i] += other.components[i]
+	}
+}
+
+// Substracts other vector from this vector
+func (v *VecN[T]) Sub(other *VecN[T]) {
+	v.mustMatch(other)
+	for i := range v.components {
+		v.components[i] -= other.components[i]
+	}
+}
+
+// Multiplies the vector with given scalar factor.
+func (v *VecN[T]) Multiply(factor T) {
+	for i := range v.components {
+		v.components[i] *= factor
+	}
+}
+
+// Divides the vector with given scalar factor.
+func (v *VecN[T]) Divide(factor T) {
+	for i := range v.components {
+		v.components[i] /= factor
+	}
+}
+
+// Normalize the vector to length 1 (make it a unit vector). Zero vector is
+// left as is.
+func (v *VecN[T]) Normalize() {
+	length := math.Sqrt(float64(v.DotProduct(v)))
+	if length == 0 {
+		return
+	}
+	for i, c := range v.components {
+		v.components[i] = T(rounded(float64(c) / length))
+	}
+}
+
+// Calculates the Euclidean distance this vector and other.
+func (v *VecN[T]) Distance(other *VecN[T]) T {
+	v.mustMatch(other)
+	var sum float64
+	for i, c := range v.components {
+		d := float64(c - other.components[i])
+		sum += d * d
+	}
+	return T(sqrt(sum))
+}
+
+// Calculates dot product of this and other vector.
+func (v *VecN[T]) DotProduct(other *VecN[T]) T {
+	v.mustMatch(other)
+	var ret T
+	for i, c := range v.components {
+		ret += c * other.components[i]
+	}
+	return ret
+}
+
+// Calculates linear interpolation from the vector to other vector.
+func (v *VecN[T]) Lerp(other *VecN[T], amount T) {
+	v.mustMatch(other)
+	for i := range v.components {
+		v.components[i] += (other.components[i] - v.components[i]) * amount
+	}
+}